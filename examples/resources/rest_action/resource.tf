@@ -0,0 +1,29 @@
+# Copyright (c) HashiCorp, Inc.
+
+# Purge the CDN cache whenever the site content changes
+resource "rest_action" "purge_cache" {
+  endpoint = "/api/cdn/purge"
+  body     = jsonencode({ paths = ["/*"] })
+
+  triggers = {
+    content = filesha256("${path.module}/site.tar.gz")
+  }
+}
+
+# Rotate an API key when its version is bumped, and revoke it on destroy
+resource "rest_action" "rotate_key" {
+  endpoint = "/api/keys/deploy/rotate"
+
+  triggers = {
+    version = "3"
+  }
+
+  status_policy = {
+    retry          = ["423"]
+    retry_interval = 10
+  }
+
+  on_destroy = {
+    endpoint = "/api/keys/deploy/revoke"
+  }
+}
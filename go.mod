@@ -3,11 +3,13 @@ module terraform-provider-rest
 go 1.23.0
 
 require (
+	github.com/andybalholm/brotli v1.1.1
 	github.com/hashicorp/terraform-plugin-framework v1.15.0
 	github.com/hashicorp/terraform-plugin-framework-validators v0.18.0
 	github.com/hashicorp/terraform-plugin-go v0.28.0
 	github.com/hashicorp/terraform-plugin-log v0.9.0
 	github.com/hashicorp/terraform-plugin-testing v1.13.2
+	github.com/klauspost/compress v1.18.0
 	golang.org/x/crypto v0.39.0
 )
 
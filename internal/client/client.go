@@ -5,12 +5,15 @@ import (
 	"context"
 	"crypto/tls"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptrace"
 	"net/url"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -26,10 +29,16 @@ type HTTPClient interface {
 type RestClient struct {
 	baseURL    string
 	httpClient HTTPClient
-	headers    map[string]string
-	timeout    time.Duration
-	retries    int
-	userAgent  string
+	// insecureHTTPClient skips certificate verification for requests with
+	// RequestOptions.Insecure; it shares the TLS authentication settings
+	insecureHTTPClient HTTPClient
+	headers            map[string]string
+	timeout            time.Duration
+	retries            int
+	userAgent          string
+	allowedHosts       []string
+	maxResponseBytes   int64
+	compression        string
 }
 
 // Config holds the configuration for the REST client
@@ -56,6 +65,13 @@ type Config struct {
 	MaxIdleConns      int
 	IdleConnTimeout   time.Duration
 	DisableKeepAlives bool
+	// AllowedHosts lists additional hosts (exact or "*.example.com") that requests
+	// may target besides the host of BaseURL
+	AllowedHosts []string
+	// MaxResponseBytes limits the size of buffered response bodies (0 = unlimited)
+	MaxResponseBytes int64
+	// RequestCompression compresses request bodies ("gzip" or "zstd")
+	RequestCompression string
 }
 
 // NewRestClient creates a new REST client with the provided configuration
@@ -119,6 +135,18 @@ func NewRestClient(config Config) (*RestClient, error) {
 		Transport: transport,
 	}
 
+	// Requests may skip certificate verification individually; they get their
+	// own transport so verified connections are never reused for them
+	insecureHTTPClient := httpClient
+	if !config.Insecure {
+		insecureTransport := transport.Clone()
+		insecureTransport.TLSClientConfig.InsecureSkipVerify = true
+		insecureHTTPClient = &http.Client{
+			Timeout:   config.Timeout,
+			Transport: insecureTransport,
+		}
+	}
+
 	// Initialize headers
 	headers := make(map[string]string)
 	headers["User-Agent"] = config.UserAgent
@@ -135,14 +163,31 @@ func NewRestClient(config Config) (*RestClient, error) {
 		headers[k] = v
 	}
 
-	return &RestClient{
-		baseURL:    strings.TrimRight(config.BaseURL, "/"),
-		httpClient: httpClient,
-		headers:    headers,
-		timeout:    config.Timeout,
-		retries:    config.RetryAttempts,
-		userAgent:  config.UserAgent,
-	}, nil
+	restClient := &RestClient{
+		baseURL:            strings.TrimRight(config.BaseURL, "/"),
+		httpClient:         httpClient,
+		insecureHTTPClient: insecureHTTPClient,
+		headers:            headers,
+		timeout:            config.Timeout,
+		retries:            config.RetryAttempts,
+		userAgent:          config.UserAgent,
+		allowedHosts:       config.AllowedHosts,
+		maxResponseBytes:   config.MaxResponseBytes,
+		compression:        config.RequestCompression,
+	}
+
+	// Refuse redirects to hosts that are not allowed so credentials are never
+	// forwarded to third parties
+	checkRedirect := func(req *http.Request, via []*http.Request) error {
+		if len(via) >= 10 {
+			return fmt.Errorf("stopped after 10 redirects")
+		}
+		return restClient.checkHost(req.URL)
+	}
+	httpClient.CheckRedirect = checkRedirect
+	insecureHTTPClient.CheckRedirect = checkRedirect
+
+	return restClient, nil
 }
 
 // RequestOptions holds options for HTTP requests
@@ -152,8 +197,24 @@ type RequestOptions struct {
 	Body        []byte
 	Headers     map[string]string
 	QueryParams map[string]string
-	Timeout     time.Duration
-	Retries     int
+	// HeaderValues holds headers that are sent once per value, in order
+	HeaderValues map[string][]string
+	// QueryValues holds query parameters that are repeated once per value (?tag=a&tag=b)
+	QueryValues map[string][]string
+	// RawQuery is appended to the query string verbatim, without re-encoding
+	RawQuery string
+	Timeout  time.Duration
+	Retries  int
+	// MaxResponseBytes overrides the client limit for buffered response bodies
+	MaxResponseBytes int64
+	// StreamResponse hashes the response body instead of buffering it; Body is left empty
+	StreamResponse bool
+	// SpoolPath, when streaming, writes the response body to this file
+	SpoolPath string
+	// Compression overrides the client request compression ("gzip", "zstd" or "none")
+	Compression string
+	// Insecure skips TLS certificate verification for this request
+	Insecure bool
 }
 
 // Response holds the HTTP response data
@@ -162,20 +223,125 @@ type Response struct {
 	Body       []byte
 	Headers    map[string][]string
 	Request    *http.Request
+	// URL is the URL of the final request, after any redirects
+	URL     *url.URL
+	Timings Timings
+	// Size is the length of the response body in bytes
+	Size int64
+	// SHA256 is the hex-encoded SHA-256 digest of the response body
+	SHA256 string
+	// Streamed reports that the body was hashed or spooled rather than buffered
+	Streamed bool
+}
+
+// Timings holds connection telemetry for a request. Phase durations describe the
+// final attempt; Total and Attempts cover all attempts including retry backoff.
+type Timings struct {
+	DNSLookup        time.Duration
+	Connect          time.Duration
+	TLSHandshake     time.Duration
+	TimeToFirstByte  time.Duration
+	Total            time.Duration
+	Attempts         int
+	ConnectionReused bool
+}
+
+// timingTracer records httptrace events for a single request attempt
+type timingTracer struct {
+	mu       sync.Mutex
+	start    time.Time
+	dnsStart time.Time
+	connect  time.Time
+	tlsStart time.Time
+	timings  Timings
+}
+
+// newTimingTracer creates a tracer whose clock starts now
+func newTimingTracer() *timingTracer {
+	return &timingTracer{start: time.Now()}
+}
+
+// clientTrace returns the httptrace hooks that feed the tracer
+func (t *timingTracer) clientTrace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.dnsStart = time.Now()
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.timings.DNSLookup = time.Since(t.dnsStart)
+		},
+		ConnectStart: func(string, string) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.connect = time.Now()
+		},
+		ConnectDone: func(string, string, error) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.timings.Connect = time.Since(t.connect)
+		},
+		TLSHandshakeStart: func() {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.tlsStart = time.Now()
+		},
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.timings.TLSHandshake = time.Since(t.tlsStart)
+		},
+		GotConn: func(info httptrace.GotConnInfo) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.timings.ConnectionReused = info.Reused
+		},
+		GotFirstResponseByte: func() {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.timings.TimeToFirstByte = time.Since(t.start)
+		},
+	}
+}
+
+// result returns a copy of the recorded timings
+func (t *timingTracer) result() Timings {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.timings
 }
 
 // Do executes an HTTP request with retry logic and proper error handling
 func (c *RestClient) Do(ctx context.Context, options RequestOptions) (*Response, error) {
 	// Build full URL
-	fullURL, err := c.buildURL(options.Endpoint, options.QueryParams)
+	fullURL, err := c.buildRequestURL(options)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build URL: %w", err)
 	}
 
+	// Compress the request body if configured
+	requestBody := options.Body
+	compression := c.compression
+	if options.Compression != "" {
+		compression = options.Compression
+	}
+	if compression == "none" || len(requestBody) == 0 {
+		compression = ""
+	}
+	if compression != "" {
+		requestBody, err = compressBody(compression, requestBody)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compress request body: %w", err)
+		}
+	}
+
 	// Create request body
 	var body io.Reader
-	if options.Body != nil {
-		body = bytes.NewReader(options.Body)
+	if requestBody != nil {
+		body = bytes.NewReader(requestBody)
 	}
 
 	// Create HTTP request
@@ -186,6 +352,16 @@ func (c *RestClient) Do(ctx context.Context, options RequestOptions) (*Response,
 
 	// Set headers
 	c.setHeaders(req, options.Headers)
+	c.setHeaderValues(req, options.HeaderValues)
+	if compression != "" {
+		req.Header.Set("Content-Encoding", compression)
+	}
+
+	// Advertise every coding the client decodes; setting this header disables the
+	// transport's built-in gzip handling, so decoding happens in executeWithRetry
+	if req.Header.Get("Accept-Encoding") == "" {
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+	}
 
 	// Set timeout if specified
 	timeout := c.timeout
@@ -199,36 +375,177 @@ func (c *RestClient) Do(ctx context.Context, options RequestOptions) (*Response,
 		retries = options.Retries
 	}
 
+	// Determine how the response body is consumed
+	handling := bodyHandling{
+		maxBytes:  c.maxResponseBytes,
+		stream:    options.StreamResponse,
+		spoolPath: options.SpoolPath,
+	}
+	if options.MaxResponseBytes > 0 {
+		handling.maxBytes = options.MaxResponseBytes
+	}
+
+	// Skip certificate verification if requested
+	httpClient := c.httpClient
+	if options.Insecure && c.insecureHTTPClient != nil {
+		httpClient = c.insecureHTTPClient
+	}
+
 	// Execute request with retry logic
-	return c.executeWithRetry(ctx, req, retries, timeout)
+	return c.executeWithRetry(ctx, httpClient, req, retries, timeout, handling)
 }
 
 // buildURL constructs the full URL with query parameters
 func (c *RestClient) buildURL(endpoint string, queryParams map[string]string) (string, error) {
-	// Clean endpoint
-	endpoint = strings.TrimPrefix(endpoint, "/")
-
-	// Build base URL
-	fullURL := fmt.Sprintf("%s/%s", c.baseURL, endpoint)
+	return c.buildRequestURL(RequestOptions{Endpoint: endpoint, QueryParams: queryParams})
+}
 
-	// Parse URL for query parameters
-	parsedURL, err := url.Parse(fullURL)
+// buildRequestURL constructs the full URL for a request, including single-value,
+// multi-value and raw query parameters
+func (c *RestClient) buildRequestURL(options RequestOptions) (string, error) {
+	parsedURL, err := c.ResolveURL(options.Endpoint)
 	if err != nil {
 		return "", err
 	}
 
-	// Add query parameters
-	if len(queryParams) > 0 {
+	// Add query parameters. Values already present in the endpoint are only
+	// re-encoded when additional parameters have to be merged in.
+	if len(options.QueryParams) > 0 || len(options.QueryValues) > 0 {
 		values := parsedURL.Query()
-		for k, v := range queryParams {
+		for k, v := range options.QueryParams {
 			values.Add(k, v)
 		}
+		for k, vs := range options.QueryValues {
+			for _, v := range vs {
+				values.Add(k, v)
+			}
+		}
 		parsedURL.RawQuery = values.Encode()
 	}
 
+	// Append the raw query verbatim so pre-encoded and order-sensitive values survive
+	if rawQuery := strings.TrimPrefix(options.RawQuery, "?"); rawQuery != "" {
+		if parsedURL.RawQuery != "" {
+			parsedURL.RawQuery += "&" + rawQuery
+		} else {
+			parsedURL.RawQuery = rawQuery
+		}
+	}
+
 	return parsedURL.String(), nil
 }
 
+// ResolveURL resolves an endpoint as a URI reference (RFC 3986) against the base URL
+// and verifies that the resulting host is allowed.
+//
+// Absolute URLs ("https://host/path") and network-path references ("//host/path")
+// are used as-is. Every other endpoint is resolved relative to the base URL as a
+// directory, so "/users" and "users" both append to the base path while
+// "../v2/items" steps out of it.
+func (c *RestClient) ResolveURL(endpoint string) (*url.URL, error) {
+	base, err := url.Parse(c.baseURL + "/")
+	if err != nil {
+		return nil, err
+	}
+
+	var ref *url.URL
+	if isAbsoluteReference(endpoint) {
+		ref, err = url.Parse(endpoint)
+	} else {
+		// Prefix with "./" so a colon in the first segment ("items:batch") is
+		// not mistaken for a scheme
+		ref, err = url.Parse("./" + strings.TrimLeft(endpoint, "/"))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := base.ResolveReference(ref)
+	if err := c.checkHost(resolved); err != nil {
+		return nil, err
+	}
+
+	return resolved, nil
+}
+
+// ResolveLocation resolves a Location header value from a response against the
+// URL of the request that produced it. Relative values ("/items/1", "1") and
+// absolute URLs are both accepted; the result must pass the allowed hosts check.
+func (c *RestClient) ResolveLocation(response *Response, location string) (*url.URL, error) {
+	ref, err := url.Parse(location)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Location header %q: %w", location, err)
+	}
+
+	base := response.URL
+	if base == nil && response.Request != nil {
+		base = response.Request.URL
+	}
+	if base == nil {
+		return nil, fmt.Errorf("cannot resolve Location header %q without a request URL", location)
+	}
+
+	resolved := base.ResolveReference(ref)
+	if err := c.checkHost(resolved); err != nil {
+		return nil, err
+	}
+
+	return resolved, nil
+}
+
+// isAbsoluteReference reports whether an endpoint is an absolute http(s) URL or a
+// network-path reference
+func isAbsoluteReference(endpoint string) bool {
+	if strings.HasPrefix(endpoint, "//") {
+		return true
+	}
+	lower := strings.ToLower(endpoint)
+	return strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://")
+}
+
+// checkHost returns an error unless the URL targets the base URL host or one of
+// the configured allowed hosts. When the base URL uses https, so must the
+// target, so credentials are never sent in cleartext.
+func (c *RestClient) checkHost(target *url.URL) error {
+	if target.Scheme != "http" && target.Scheme != "https" {
+		return fmt.Errorf("unsupported URL scheme %q", target.Scheme)
+	}
+
+	base, err := url.Parse(c.baseURL)
+	if err != nil {
+		return err
+	}
+	if strings.EqualFold(base.Scheme, "https") && target.Scheme != "https" {
+		return fmt.Errorf("refusing to downgrade from https to %s for %q", target.Scheme, target.Redacted())
+	}
+	if strings.EqualFold(target.Host, base.Host) {
+		return nil
+	}
+
+	for _, pattern := range c.allowedHosts {
+		if hostMatches(pattern, target) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("host %q is not the API host and is not listed in allowed_hosts", target.Host)
+}
+
+// hostMatches checks a host against an allowed_hosts pattern. Patterns without a
+// port match any port; a leading "*." matches any subdomain.
+func hostMatches(pattern string, target *url.URL) bool {
+	pattern = strings.ToLower(strings.TrimSpace(pattern))
+	host := strings.ToLower(target.Hostname())
+	if strings.Contains(strings.TrimPrefix(pattern, "*."), ":") {
+		host = strings.ToLower(target.Host)
+	}
+
+	if suffix, ok := strings.CutPrefix(pattern, "*."); ok {
+		return strings.HasSuffix(host, "."+suffix)
+	}
+	return host == pattern
+}
+
 // setHeaders sets the request headers
 func (c *RestClient) setHeaders(req *http.Request, customHeaders map[string]string) {
 	// Set default headers
@@ -242,22 +559,45 @@ func (c *RestClient) setHeaders(req *http.Request, customHeaders map[string]stri
 	}
 }
 
+// setHeaderValues adds multi-value headers, replacing any single value set for the same name
+func (c *RestClient) setHeaderValues(req *http.Request, headerValues map[string][]string) {
+	for k, vs := range headerValues {
+		req.Header.Del(k)
+		for _, v := range vs {
+			req.Header.Add(k, v)
+		}
+	}
+}
+
 // executeWithRetry executes the request with exponential backoff retry logic
-func (c *RestClient) executeWithRetry(ctx context.Context, req *http.Request, retries int, timeout time.Duration) (*Response, error) {
+func (c *RestClient) executeWithRetry(ctx context.Context, httpClient HTTPClient, req *http.Request, retries int, timeout time.Duration, handling bodyHandling) (*Response, error) {
 	var lastErr error
+	start := time.Now()
 
 	for attempt := 0; attempt < retries; attempt++ {
 		// Create a new context with timeout for this attempt
 		attemptCtx, cancel := context.WithTimeout(ctx, timeout)
 
-		// Clone the request for retry attempts
-		clonedReq := req.Clone(attemptCtx)
+		// Trace connection phases of this attempt
+		tracer := newTimingTracer()
+
+		// Clone the request for retry attempts. Clone shares the body reader, so a
+		// fresh copy is needed or retries would send an empty body.
+		clonedReq := req.Clone(httptrace.WithClientTrace(attemptCtx, tracer.clientTrace()))
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				cancel()
+				return nil, fmt.Errorf("failed to reset request body: %w", err)
+			}
+			clonedReq.Body = body
+		}
 
 		// Execute the request
-		resp, err := c.httpClient.Do(clonedReq)
-		cancel()
+		resp, err := httpClient.Do(clonedReq)
 
 		if err != nil {
+			cancel()
 			lastErr = err
 
 			// Log the retry attempt
@@ -283,11 +623,19 @@ func (c *RestClient) executeWithRetry(ctx context.Context, req *http.Request, re
 			continue
 		}
 
-		// Read response body
-		body, err := io.ReadAll(resp.Body)
+		// Read response body before releasing the attempt context so the
+		// connection can be returned to the pool
+		result, err := c.readResponseBody(resp, handling)
 		_ = resp.Body.Close()
+		cancel()
 
 		if err != nil {
+			// Size limits are deterministic, so retrying cannot help
+			var tooLarge *ResponseTooLargeError
+			if errors.As(err, &tooLarge) {
+				return nil, err
+			}
+
 			lastErr = fmt.Errorf("failed to read response body: %w", err)
 
 			// Log the error
@@ -328,14 +676,36 @@ func (c *RestClient) executeWithRetry(ctx context.Context, req *http.Request, re
 			}
 		}
 
+		// Collect timings for the final attempt
+		timings := tracer.result()
+		timings.Total = time.Since(start)
+		timings.Attempts = attempt + 1
+
 		// Create response
 		response := &Response{
 			StatusCode: resp.StatusCode,
-			Body:       body,
+			Body:       result.body,
 			Headers:    resp.Header,
 			Request:    req,
+			URL:        resp.Request.URL,
+			Timings:    timings,
+			Size:       result.size,
+			SHA256:     result.sha256,
+			Streamed:   handling.stream,
 		}
 
+		tflog.Debug(ctx, "HTTP request timings", map[string]interface{}{
+			"method":                req.Method,
+			"url":                   req.URL.String(),
+			"dns_lookup_ms":         DurationMillis(timings.DNSLookup),
+			"connect_ms":            DurationMillis(timings.Connect),
+			"tls_handshake_ms":      DurationMillis(timings.TLSHandshake),
+			"time_to_first_byte_ms": DurationMillis(timings.TimeToFirstByte),
+			"total_ms":              DurationMillis(timings.Total),
+			"attempts":              timings.Attempts,
+			"connection_reused":     timings.ConnectionReused,
+		})
+
 		// Log successful request
 		tflog.Trace(ctx, "HTTP request completed", map[string]interface{}{
 			"method":      req.Method,
@@ -350,6 +720,40 @@ func (c *RestClient) executeWithRetry(ctx context.Context, req *http.Request, re
 	return nil, fmt.Errorf("request failed after %d attempts: %w", retries, lastErr)
 }
 
+// readResponseBody decodes the response Content-Encoding and consumes the body. Once
+// decoded, the encoding headers no longer describe the body and are removed.
+func (c *RestClient) readResponseBody(resp *http.Response, handling bodyHandling) (bodyResult, error) {
+	contentEncoding := resp.Header.Get("Content-Encoding")
+	contentLength := resp.ContentLength
+
+	// Responses without a body carry nothing to decode
+	if resp.ContentLength == 0 || resp.StatusCode == http.StatusNoContent || resp.StatusCode == http.StatusNotModified ||
+		(resp.Request != nil && resp.Request.Method == http.MethodHead) {
+		contentEncoding = ""
+	}
+
+	reader, release, err := decodeBody(contentEncoding, resp.Body)
+	if err != nil {
+		return bodyResult{}, err
+	}
+	defer release()
+
+	if contentEncoding != "" {
+		resp.Header.Del("Content-Encoding")
+		resp.Header.Del("Content-Length")
+		// The announced length is the encoded size
+		contentLength = -1
+	}
+
+	return handling.read(reader, contentLength)
+}
+
+// DurationMillis converts a duration to fractional milliseconds, the unit
+// timings are reported in
+func DurationMillis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
 // calculateBackoff calculates the exponential backoff delay
 func (c *RestClient) calculateBackoff(attempt int) time.Duration {
 	// Base delay of 1 second with exponential backoff
@@ -1,12 +1,25 @@
 package client
 
 import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
 )
 
 func TestNewRestClient(t *testing.T) {
@@ -364,6 +377,725 @@ func TestRestClient_BuildURL(t *testing.T) {
 	}
 }
 
+func TestRestClient_BuildRequestURL(t *testing.T) {
+	client, _ := NewRestClient(Config{
+		BaseURL:     "https://api.example.com",
+		Token:       "test",
+		TokenHeader: "Authorization",
+	})
+
+	tests := []struct {
+		name     string
+		options  RequestOptions
+		expected string
+	}{
+		{
+			name: "repeated query parameter keeps value order",
+			options: RequestOptions{
+				Endpoint:    "/items",
+				QueryValues: map[string][]string{"tag": {"b", "a"}},
+			},
+			expected: "https://api.example.com/items?tag=b&tag=a",
+		},
+		{
+			name: "single and repeated values for the same key are merged",
+			options: RequestOptions{
+				Endpoint:    "/items",
+				QueryParams: map[string]string{"tag": "x"},
+				QueryValues: map[string][]string{"tag": {"y", "z"}},
+			},
+			expected: "https://api.example.com/items?tag=x&tag=y&tag=z",
+		},
+		{
+			name: "special characters are encoded in query values",
+			options: RequestOptions{
+				Endpoint:    "/items",
+				QueryParams: map[string]string{"q": "a b&c=d"},
+			},
+			expected: "https://api.example.com/items?q=a+b%26c%3Dd",
+		},
+		{
+			name: "pre-encoded value is double encoded in query params",
+			options: RequestOptions{
+				Endpoint:    "/items",
+				QueryParams: map[string]string{"filter": "a%2Cb"},
+			},
+			expected: "https://api.example.com/items?filter=a%252Cb",
+		},
+		{
+			name: "raw query is appended verbatim",
+			options: RequestOptions{
+				Endpoint: "/items",
+				RawQuery: "z=1&filter=a%2Cb&a=2",
+			},
+			expected: "https://api.example.com/items?z=1&filter=a%2Cb&a=2",
+		},
+		{
+			name: "raw query with leading question mark",
+			options: RequestOptions{
+				Endpoint: "/items",
+				RawQuery: "?sig=abc%3D",
+			},
+			expected: "https://api.example.com/items?sig=abc%3D",
+		},
+		{
+			name: "raw query follows encoded parameters",
+			options: RequestOptions{
+				Endpoint:    "/items",
+				QueryParams: map[string]string{"limit": "10"},
+				RawQuery:    "filter=a%2Cb",
+			},
+			expected: "https://api.example.com/items?limit=10&filter=a%2Cb",
+		},
+		{
+			name: "endpoint query string is preserved without extra parameters",
+			options: RequestOptions{
+				Endpoint: "/items?b=2&a=1",
+			},
+			expected: "https://api.example.com/items?b=2&a=1",
+		},
+		{
+			name: "raw query is appended to endpoint query string",
+			options: RequestOptions{
+				Endpoint: "/items?b=2",
+				RawQuery: "a=1",
+			},
+			expected: "https://api.example.com/items?b=2&a=1",
+		},
+		{
+			name: "empty repeated value list adds nothing",
+			options: RequestOptions{
+				Endpoint:    "/items",
+				QueryValues: map[string][]string{"tag": {}},
+			},
+			expected: "https://api.example.com/items",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := client.buildRequestURL(tt.options)
+			if err != nil {
+				t.Errorf("Unexpected error: %s", err)
+				return
+			}
+
+			if result != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestRestClient_ResolveURL(t *testing.T) {
+	client, _ := NewRestClient(Config{
+		BaseURL:      "https://api.example.com/v1",
+		Token:        "test",
+		TokenHeader:  "Authorization",
+		AllowedHosts: []string{"files.example.net", "*.cdn.example.com", "localhost:8443"},
+	})
+
+	tests := []struct {
+		name      string
+		endpoint  string
+		expected  string
+		expectErr bool
+	}{
+		{name: "leading slash appends to base path", endpoint: "/users", expected: "https://api.example.com/v1/users"},
+		{name: "relative path appends to base path", endpoint: "users/42", expected: "https://api.example.com/v1/users/42"},
+		{name: "empty endpoint is the base", endpoint: "", expected: "https://api.example.com/v1/"},
+		{name: "parent reference", endpoint: "../v2/items", expected: "https://api.example.com/v2/items"},
+		{name: "dot segments are removed", endpoint: "items/./a/../b", expected: "https://api.example.com/v1/items/b"},
+		{name: "colon in first segment is not a scheme", endpoint: "items:batch", expected: "https://api.example.com/v1/items:batch"},
+		{name: "query only reference", endpoint: "?page=2", expected: "https://api.example.com/v1/?page=2"},
+		{name: "escaped path is preserved", endpoint: "/items/a%2Fb", expected: "https://api.example.com/v1/items/a%2Fb"},
+		{name: "absolute URL on the API host", endpoint: "https://api.example.com/other?page=2", expected: "https://api.example.com/other?page=2"},
+		{name: "absolute URL on an allowed host", endpoint: "https://files.example.net/export/1", expected: "https://files.example.net/export/1"},
+		{name: "wildcard allowed host", endpoint: "https://eu.cdn.example.com/a", expected: "https://eu.cdn.example.com/a"},
+		{name: "wildcard does not match the bare domain", endpoint: "https://cdn.example.com/a", expectErr: true},
+		{name: "allowed host with port", endpoint: "https://localhost:8443/x", expected: "https://localhost:8443/x"},
+		{name: "allowed host with other port", endpoint: "https://localhost:9000/x", expectErr: true},
+		{name: "network-path reference to the API host", endpoint: "//api.example.com/v3", expected: "https://api.example.com/v3"},
+		{name: "absolute URL on a foreign host", endpoint: "https://evil.example.org/steal", expectErr: true},
+		{name: "network-path reference to a foreign host", endpoint: "//evil.example.org/steal", expectErr: true},
+		{name: "http URL on the API host", endpoint: "http://api.example.com/v1/users", expectErr: true},
+		{name: "http URL on an allowed host", endpoint: "http://files.example.net/export/1", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := client.ResolveURL(tt.endpoint)
+
+			if tt.expectErr {
+				if err == nil {
+					t.Errorf("Expected error but got %s", result)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("Unexpected error: %s", err)
+				return
+			}
+
+			if result.String() != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, result.String())
+			}
+		})
+	}
+}
+
+func TestRestClient_RedirectToForeignHost(t *testing.T) {
+	foreign := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Request must not reach a host outside allowed_hosts, got Authorization=%q", r.Header.Get("Authorization"))
+		w.WriteHeader(200)
+	}))
+	defer foreign.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, strings.Replace(foreign.URL, "127.0.0.1", "localhost", 1)+"/landing", http.StatusFound)
+	}))
+	defer server.Close()
+
+	client, err := NewRestClient(Config{
+		BaseURL:       server.URL,
+		Token:         "test-token",
+		TokenHeader:   "Authorization",
+		RetryAttempts: 1,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %s", err)
+	}
+
+	_, err = client.Do(context.Background(), RequestOptions{Method: "GET", Endpoint: "/start"})
+	if err == nil || !strings.Contains(err.Error(), "allowed_hosts") {
+		t.Errorf("Expected allowed_hosts error, got %v", err)
+	}
+}
+
+func TestRestClient_RedirectDowngrade(t *testing.T) {
+	plain := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Request must not be downgraded to http, got Authorization=%q", r.Header.Get("Authorization"))
+		w.WriteHeader(200)
+	}))
+	defer plain.Close()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, plain.URL+"/landing", http.StatusFound)
+	}))
+	defer server.Close()
+
+	plainURL, _ := url.Parse(plain.URL)
+	client, err := NewRestClient(Config{
+		BaseURL:       server.URL,
+		Token:         "test-token",
+		TokenHeader:   "Authorization",
+		RetryAttempts: 1,
+		AllowedHosts:  []string{plainURL.Host},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %s", err)
+	}
+
+	_, err = client.Do(context.Background(), RequestOptions{Method: "GET", Endpoint: "/start", Insecure: true})
+	if err == nil || !strings.Contains(err.Error(), "downgrade") {
+		t.Errorf("Expected downgrade error, got %v", err)
+	}
+}
+
+func TestRestClient_InsecureRequest(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(200)
+	}))
+	defer server.Close()
+
+	client, err := NewRestClient(Config{BaseURL: server.URL, RetryAttempts: 1})
+	if err != nil {
+		t.Fatalf("Failed to create client: %s", err)
+	}
+
+	// The test server certificate is self-signed, so only insecure requests succeed
+	_, err = client.Do(context.Background(), RequestOptions{Method: "GET", Endpoint: "/verified"})
+	if err == nil || !strings.Contains(err.Error(), "certificate") {
+		t.Errorf("Expected certificate error, got %v", err)
+	}
+
+	response, err := client.Do(context.Background(), RequestOptions{Method: "GET", Endpoint: "/insecure", Insecure: true})
+	if err != nil {
+		t.Fatalf("Expected insecure request to succeed, got %s", err)
+	}
+	if response.StatusCode != 200 {
+		t.Errorf("Expected status 200, got %d", response.StatusCode)
+	}
+
+	// Verification still applies to later requests
+	_, err = client.Do(context.Background(), RequestOptions{Method: "GET", Endpoint: "/verified"})
+	if err == nil {
+		t.Errorf("Expected certificate error after an insecure request")
+	}
+}
+
+func TestRestClient_ResolveLocation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/old" {
+			http.Redirect(w, r, "/api/v2/items", http.StatusTemporaryRedirect)
+			return
+		}
+		w.WriteHeader(201)
+	}))
+	defer server.Close()
+
+	client, err := NewRestClient(Config{
+		BaseURL:      server.URL + "/api",
+		AllowedHosts: []string{"objects.example.com"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %s", err)
+	}
+
+	response, err := client.Do(context.Background(), RequestOptions{Method: "POST", Endpoint: "/old"})
+	if err != nil {
+		t.Fatalf("Request failed: %s", err)
+	}
+	if response.URL.Path != "/api/v2/items" {
+		t.Fatalf("Expected final URL path /api/v2/items, got %s", response.URL.Path)
+	}
+
+	tests := []struct {
+		name      string
+		location  string
+		expected  string
+		expectErr bool
+	}{
+		{name: "absolute path", location: "/api/v2/items/42", expected: server.URL + "/api/v2/items/42"},
+		{name: "relative to final URL", location: "items/42", expected: server.URL + "/api/v2/items/42"},
+		{name: "absolute URL on base host", location: server.URL + "/other/7", expected: server.URL + "/other/7"},
+		{name: "absolute URL on allowed host", location: "https://objects.example.com/7", expected: "https://objects.example.com/7"},
+		{name: "absolute URL on foreign host", location: "https://evil.example.com/7", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolved, err := client.ResolveLocation(response, tt.location)
+
+			if tt.expectErr {
+				if err == nil {
+					t.Errorf("Expected error, got %s", resolved)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if resolved.String() != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, resolved)
+			}
+		})
+	}
+}
+
+func TestRestClient_HeaderValues(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		values := r.Header.Values("X-Tag")
+		if len(values) != 2 || values[0] != "first" || values[1] != "second" {
+			t.Errorf("Expected X-Tag values [first second], got %v", values)
+		}
+		if accept := r.Header.Values("Accept"); len(accept) != 1 || accept[0] != "text/plain" {
+			t.Errorf("Expected repeated header to replace default Accept, got %v", accept)
+		}
+		if r.URL.RawQuery != "tag=a&tag=b&sig=x%2Fy" {
+			t.Errorf("Expected raw query tag=a&tag=b&sig=x%%2Fy, got %s", r.URL.RawQuery)
+		}
+		w.WriteHeader(200)
+	}))
+	defer server.Close()
+
+	client, err := NewRestClient(Config{
+		BaseURL:     server.URL,
+		Token:       "test-token",
+		TokenHeader: "Authorization",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %s", err)
+	}
+
+	_, err = client.Do(context.Background(), RequestOptions{
+		Method:   "GET",
+		Endpoint: "/test",
+		HeaderValues: map[string][]string{
+			"X-Tag":  {"first", "second"},
+			"Accept": {"text/plain"},
+		},
+		QueryValues: map[string][]string{"tag": {"a", "b"}},
+		RawQuery:    "sig=x%2Fy",
+	})
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+}
+
+func TestRestClient_Timings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(20 * time.Millisecond)
+		w.WriteHeader(200)
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	client, err := NewRestClient(Config{
+		BaseURL:     server.URL,
+		Token:       "test-token",
+		TokenHeader: "Authorization",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %s", err)
+	}
+
+	ctx := context.Background()
+	first, err := client.Do(ctx, RequestOptions{Method: "GET", Endpoint: "/test"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if first.Timings.Attempts != 1 {
+		t.Errorf("Expected 1 attempt, got %d", first.Timings.Attempts)
+	}
+	if first.Timings.ConnectionReused {
+		t.Error("Expected first request to open a new connection")
+	}
+	if first.Timings.TimeToFirstByte < 20*time.Millisecond {
+		t.Errorf("Expected time to first byte of at least 20ms, got %s", first.Timings.TimeToFirstByte)
+	}
+	if first.Timings.Total < first.Timings.TimeToFirstByte {
+		t.Errorf("Expected total %s to cover time to first byte %s", first.Timings.Total, first.Timings.TimeToFirstByte)
+	}
+
+	second, err := client.Do(ctx, RequestOptions{Method: "GET", Endpoint: "/test"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if !second.Timings.ConnectionReused {
+		t.Error("Expected second request to reuse the pooled connection")
+	}
+}
+
+func TestRestClient_MaxResponseBytes(t *testing.T) {
+	payload := strings.Repeat("x", 1024)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/chunked" {
+			// Flushing before writing forces chunked encoding without Content-Length
+			w.(http.Flusher).Flush()
+		}
+		_, _ = w.Write([]byte(payload))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name        string
+		clientLimit int64
+		optionLimit int64
+		endpoint    string
+		expectErr   bool
+	}{
+		{name: "unlimited by default", endpoint: "/fixed"},
+		{name: "body within limit", clientLimit: 1024, endpoint: "/fixed"},
+		{name: "content length over limit", clientLimit: 512, endpoint: "/fixed", expectErr: true},
+		{name: "chunked body over limit", clientLimit: 512, endpoint: "/chunked", expectErr: true},
+		{name: "request limit overrides client limit", clientLimit: 512, optionLimit: 2048, endpoint: "/fixed"},
+		{name: "request limit tighter than client limit", clientLimit: 2048, optionLimit: 100, endpoint: "/chunked", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewRestClient(Config{
+				BaseURL:          server.URL,
+				Token:            "test-token",
+				TokenHeader:      "Authorization",
+				MaxResponseBytes: tt.clientLimit,
+			})
+			if err != nil {
+				t.Fatalf("Failed to create client: %s", err)
+			}
+
+			response, err := client.Do(context.Background(), RequestOptions{
+				Method:           "GET",
+				Endpoint:         tt.endpoint,
+				MaxResponseBytes: tt.optionLimit,
+			})
+
+			if tt.expectErr {
+				var tooLarge *ResponseTooLargeError
+				if !errors.As(err, &tooLarge) {
+					t.Errorf("Expected ResponseTooLargeError, got %v", err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("Unexpected error: %s", err)
+				return
+			}
+
+			if response.Size != int64(len(payload)) || string(response.Body) != payload {
+				t.Errorf("Expected full %d byte body, got size %d", len(payload), response.Size)
+			}
+		})
+	}
+}
+
+func TestRestClient_StreamResponse(t *testing.T) {
+	payload := strings.Repeat("streamed-data-", 10000)
+	digest := sha256.Sum256([]byte(payload))
+	expectedSHA := hex.EncodeToString(digest[:])
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(payload))
+	}))
+	defer server.Close()
+
+	client, err := NewRestClient(Config{
+		BaseURL:          server.URL,
+		Token:            "test-token",
+		TokenHeader:      "Authorization",
+		MaxResponseBytes: 1024,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %s", err)
+	}
+
+	spoolPath := filepath.Join(t.TempDir(), "export.json")
+
+	tests := []struct {
+		name      string
+		spoolPath string
+	}{
+		{name: "hash and discard"},
+		{name: "spool to disk", spoolPath: spoolPath},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := client.Do(context.Background(), RequestOptions{
+				Method:         "GET",
+				Endpoint:       "/export",
+				StreamResponse: true,
+				SpoolPath:      tt.spoolPath,
+			})
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			if !response.Streamed || len(response.Body) != 0 {
+				t.Errorf("Expected streamed response without buffered body, got %d bytes", len(response.Body))
+			}
+			if response.Size != int64(len(payload)) {
+				t.Errorf("Expected size %d, got %d", len(payload), response.Size)
+			}
+			if response.SHA256 != expectedSHA {
+				t.Errorf("Expected SHA-256 %s, got %s", expectedSHA, response.SHA256)
+			}
+
+			if tt.spoolPath != "" {
+				spooled, err := os.ReadFile(tt.spoolPath)
+				if err != nil {
+					t.Fatalf("Failed to read spool file: %s", err)
+				}
+				if string(spooled) != payload {
+					t.Errorf("Expected spool file to contain the response body")
+				}
+			}
+		})
+	}
+}
+
+func TestRestClient_RequestCompression(t *testing.T) {
+	payload := `{"config": "` + strings.Repeat("a", 4096) + `"}`
+
+	tests := []struct {
+		name               string
+		clientCompression  string
+		requestCompression string
+		expectedEncoding   string
+	}{
+		{name: "no compression"},
+		{name: "gzip from client config", clientCompression: "gzip", expectedEncoding: "gzip"},
+		{name: "zstd from request options", requestCompression: "zstd", expectedEncoding: "zstd"},
+		{name: "request overrides client", clientCompression: "gzip", requestCompression: "zstd", expectedEncoding: "zstd"},
+		{name: "request disables client compression", clientCompression: "gzip", requestCompression: "none"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if encoding := r.Header.Get("Content-Encoding"); encoding != tt.expectedEncoding {
+					t.Errorf("Expected Content-Encoding %q, got %q", tt.expectedEncoding, encoding)
+				}
+
+				var reader io.Reader = r.Body
+				switch r.Header.Get("Content-Encoding") {
+				case "gzip":
+					gz, err := gzip.NewReader(r.Body)
+					if err != nil {
+						t.Fatalf("Failed to read gzip body: %s", err)
+					}
+					reader = gz
+				case "zstd":
+					zr, err := zstd.NewReader(r.Body)
+					if err != nil {
+						t.Fatalf("Failed to read zstd body: %s", err)
+					}
+					defer zr.Close()
+					reader = zr
+				}
+
+				received, err := io.ReadAll(reader)
+				if err != nil {
+					t.Fatalf("Failed to decode request body: %s", err)
+				}
+				if string(received) != payload {
+					t.Errorf("Expected decoded body to match payload, got %d bytes", len(received))
+				}
+				w.WriteHeader(200)
+			}))
+			defer server.Close()
+
+			client, err := NewRestClient(Config{
+				BaseURL:            server.URL,
+				Token:              "test-token",
+				TokenHeader:        "Authorization",
+				RequestCompression: tt.clientCompression,
+			})
+			if err != nil {
+				t.Fatalf("Failed to create client: %s", err)
+			}
+
+			_, err = client.Do(context.Background(), RequestOptions{
+				Method:      "POST",
+				Endpoint:    "/configs",
+				Body:        []byte(payload),
+				Compression: tt.requestCompression,
+			})
+			if err != nil {
+				t.Errorf("Unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestRestClient_ResponseDecoding(t *testing.T) {
+	payload := `{"message": "` + strings.Repeat("b", 2048) + `"}`
+
+	encoders := map[string]func(io.Writer) io.WriteCloser{
+		"gzip":    func(w io.Writer) io.WriteCloser { return gzip.NewWriter(w) },
+		"deflate": func(w io.Writer) io.WriteCloser { return zlib.NewWriter(w) },
+		"br":      func(w io.Writer) io.WriteCloser { return brotli.NewWriter(w) },
+		"zstd": func(w io.Writer) io.WriteCloser {
+			zw, _ := zstd.NewWriter(w)
+			return zw
+		},
+	}
+
+	tests := []struct {
+		name     string
+		encoding string
+	}{
+		{name: "identity", encoding: ""},
+		{name: "gzip", encoding: "gzip"},
+		{name: "deflate", encoding: "deflate"},
+		{name: "brotli", encoding: "br"},
+		{name: "zstd", encoding: "zstd"},
+		{name: "stacked gzip then brotli", encoding: "gzip, br"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if accept := r.Header.Get("Accept-Encoding"); accept != "gzip, deflate, br, zstd" {
+					t.Errorf("Expected Accept-Encoding to advertise all codings, got %q", accept)
+				}
+
+				encoded := []byte(payload)
+				if tt.encoding != "" {
+					for _, coding := range strings.Split(tt.encoding, ", ") {
+						var buf bytes.Buffer
+						writer := encoders[coding](&buf)
+						_, _ = writer.Write(encoded)
+						_ = writer.Close()
+						encoded = buf.Bytes()
+					}
+					w.Header().Set("Content-Encoding", tt.encoding)
+				}
+				w.WriteHeader(200)
+				_, _ = w.Write(encoded)
+			}))
+			defer server.Close()
+
+			client, err := NewRestClient(Config{
+				BaseURL:     server.URL,
+				Token:       "test-token",
+				TokenHeader: "Authorization",
+			})
+			if err != nil {
+				t.Fatalf("Failed to create client: %s", err)
+			}
+
+			response, err := client.Do(context.Background(), RequestOptions{Method: "GET", Endpoint: "/data"})
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			if string(response.Body) != payload {
+				t.Errorf("Expected decoded body to match payload, got %q", string(response.Body))
+			}
+			if encoding := http.Header(response.Headers).Get("Content-Encoding"); encoding != "" {
+				t.Errorf("Expected Content-Encoding to be removed after decoding, got %q", encoding)
+			}
+		})
+	}
+}
+
+func TestRestClient_RetryResendsBody(t *testing.T) {
+	attemptCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attemptCount++
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"name": "test"}` {
+			t.Errorf("Attempt %d: expected request body to be resent, got %q", attemptCount, string(body))
+		}
+		if attemptCount < 2 {
+			w.WriteHeader(503)
+			return
+		}
+		w.WriteHeader(201)
+	}))
+	defer server.Close()
+
+	client, err := NewRestClient(Config{
+		BaseURL:     server.URL,
+		Token:       "test-token",
+		TokenHeader: "Authorization",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %s", err)
+	}
+
+	response, err := client.Do(context.Background(), RequestOptions{
+		Method:   "POST",
+		Endpoint: "/items",
+		Body:     []byte(`{"name": "test"}`),
+		Retries:  2,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if response.StatusCode != 201 {
+		t.Errorf("Expected status code 201, got %d", response.StatusCode)
+	}
+}
+
 func TestRestClient_IsRetryableError(t *testing.T) {
 	client, _ := NewRestClient(Config{
 		BaseURL:     "https://api.example.com",
@@ -0,0 +1,101 @@
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+)
+
+// acceptEncoding advertises every content coding the client can decode
+const acceptEncoding = "gzip, deflate, br, zstd"
+
+// compressBody encodes a request body with the given algorithm ("gzip" or "zstd")
+func compressBody(algorithm string, body []byte) ([]byte, error) {
+	var buf bytes.Buffer
+
+	switch algorithm {
+	case "gzip":
+		writer := gzip.NewWriter(&buf)
+		if _, err := writer.Write(body); err != nil {
+			return nil, err
+		}
+		if err := writer.Close(); err != nil {
+			return nil, err
+		}
+	case "zstd":
+		writer, err := zstd.NewWriter(&buf)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := writer.Write(body); err != nil {
+			return nil, err
+		}
+		if err := writer.Close(); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported request compression %q", algorithm)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// decodeBody wraps a response body with decoders for its Content-Encoding. Multiple
+// codings are undone in reverse order of application. The returned closer releases
+// decoder resources; it does not close the underlying body.
+func decodeBody(contentEncoding string, body io.Reader) (io.Reader, func(), error) {
+	reader := body
+	var closers []func()
+	closeAll := func() {
+		for i := len(closers) - 1; i >= 0; i-- {
+			closers[i]()
+		}
+	}
+
+	codings := strings.Split(contentEncoding, ",")
+	for i := len(codings) - 1; i >= 0; i-- {
+		coding := strings.ToLower(strings.TrimSpace(codings[i]))
+
+		switch coding {
+		case "", "identity":
+			continue
+		case "gzip", "x-gzip":
+			gz, err := gzip.NewReader(reader)
+			if err != nil {
+				closeAll()
+				return nil, nil, fmt.Errorf("failed to decode gzip response: %w", err)
+			}
+			closers = append(closers, func() { _ = gz.Close() })
+			reader = gz
+		case "deflate":
+			zr, err := zlib.NewReader(reader)
+			if err != nil {
+				closeAll()
+				return nil, nil, fmt.Errorf("failed to decode deflate response: %w", err)
+			}
+			closers = append(closers, func() { _ = zr.Close() })
+			reader = zr
+		case "br":
+			reader = brotli.NewReader(reader)
+		case "zstd":
+			zr, err := zstd.NewReader(reader, zstd.WithDecoderConcurrency(1))
+			if err != nil {
+				closeAll()
+				return nil, nil, fmt.Errorf("failed to decode zstd response: %w", err)
+			}
+			closers = append(closers, zr.Close)
+			reader = zr
+		default:
+			closeAll()
+			return nil, nil, fmt.Errorf("unsupported response Content-Encoding %q", coding)
+		}
+	}
+
+	return reader, closeAll, nil
+}
@@ -0,0 +1,112 @@
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// ResponseTooLargeError is returned when a buffered response body exceeds the
+// configured maximum size
+type ResponseTooLargeError struct {
+	Limit int64
+}
+
+func (e *ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("response body exceeds the maximum of %d bytes", e.Limit)
+}
+
+// bodyHandling controls how a response body is consumed
+type bodyHandling struct {
+	// maxBytes limits buffered bodies; zero or negative means unlimited
+	maxBytes int64
+	// stream hashes the body without buffering it in memory
+	stream bool
+	// spoolPath, when streaming, receives a copy of the body on disk
+	spoolPath string
+}
+
+// bodyResult holds the outcome of consuming a response body
+type bodyResult struct {
+	body   []byte
+	size   int64
+	sha256 string
+}
+
+// read consumes the body according to the handling options
+func (h bodyHandling) read(r io.Reader, contentLength int64) (bodyResult, error) {
+	hasher := sha256.New()
+
+	if h.stream {
+		return h.readStream(r, hasher)
+	}
+
+	// Fail fast when the server announces a body that is too large
+	if h.maxBytes > 0 && contentLength > h.maxBytes {
+		return bodyResult{}, &ResponseTooLargeError{Limit: h.maxBytes}
+	}
+
+	reader := r
+	if h.maxBytes > 0 {
+		reader = io.LimitReader(r, h.maxBytes+1)
+	}
+
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return bodyResult{}, err
+	}
+	if h.maxBytes > 0 && int64(len(body)) > h.maxBytes {
+		return bodyResult{}, &ResponseTooLargeError{Limit: h.maxBytes}
+	}
+
+	_, _ = hasher.Write(body)
+	return bodyResult{
+		body:   body,
+		size:   int64(len(body)),
+		sha256: hex.EncodeToString(hasher.Sum(nil)),
+	}, nil
+}
+
+// readStream hashes the body while copying it, optionally spooling it to disk.
+// The spool file is written to a temporary file and renamed into place so a
+// failed attempt never leaves a partial file behind.
+func (h bodyHandling) readStream(r io.Reader, hasher hash.Hash) (bodyResult, error) {
+	var writer io.Writer = hasher
+	var spool *os.File
+
+	if h.spoolPath != "" {
+		var err error
+		spool, err = os.CreateTemp(filepath.Dir(h.spoolPath), "."+filepath.Base(h.spoolPath)+".tmp-*")
+		if err != nil {
+			return bodyResult{}, fmt.Errorf("failed to create spool file: %w", err)
+		}
+		defer func() {
+			_ = spool.Close()
+			_ = os.Remove(spool.Name())
+		}()
+		writer = io.MultiWriter(hasher, spool)
+	}
+
+	size, err := io.Copy(writer, r)
+	if err != nil {
+		return bodyResult{}, err
+	}
+
+	if spool != nil {
+		if err := spool.Close(); err != nil {
+			return bodyResult{}, fmt.Errorf("failed to write spool file: %w", err)
+		}
+		if err := os.Rename(spool.Name(), h.spoolPath); err != nil {
+			return bodyResult{}, fmt.Errorf("failed to move spool file into place: %w", err)
+		}
+	}
+
+	return bodyResult{
+		size:   size,
+		sha256: hex.EncodeToString(hasher.Sum(nil)),
+	}, nil
+}
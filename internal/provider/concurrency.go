@@ -0,0 +1,232 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/boolvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-rest/internal/client"
+)
+
+const (
+	lockingSourceETag      = "etag"
+	lockingSourceField     = "field"
+	lockingSourceBodyHash  = "body_hash"
+	preconditionFailedBody = "The object was changed outside Terraform since it was last read, so the request was rejected to avoid overwriting those changes. Run terraform plan again to refresh the object and review the differences, then apply."
+)
+
+// OptimisticLockingModel describes how updates and deletes detect that the object
+// changed since Terraform last read it.
+type OptimisticLockingModel struct {
+	Source     types.String `tfsdk:"source"`
+	Field      types.String `tfsdk:"field"`
+	SendInBody types.Bool   `tfsdk:"send_in_body"`
+}
+
+// optimisticLockingAttribute returns the schema for the optimistic_locking block
+func optimisticLockingAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "Refuse to update or delete the object if it changed since Terraform last read it. The version of the object is recorded in `concurrency_token` whenever it is read, created or updated.",
+		Optional:            true,
+		Attributes: map[string]schema.Attribute{
+			"source": schema.StringAttribute{
+				MarkdownDescription: "Where the version comes from: `etag` (the `ETag` response header), `field` (a field of the response body, see `field`) or `body_hash` (the SHA-256 of the response body, for APIs without versions). `etag` and `field` versions are sent as `If-Match`; `body_hash` reads the object before updating or deleting and compares the hash. Default: `etag`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(lockingSourceETag, lockingSourceField, lockingSourceBodyHash),
+				},
+			},
+			"field": schema.StringAttribute{
+				MarkdownDescription: "JSON path or pointer to the version field of the response body, e.g. `metadata.resourceVersion`. Required when `source` is `field`.",
+				Optional:            true,
+			},
+			"send_in_body": schema.BoolAttribute{
+				MarkdownDescription: "With `source = \"field\"`, write the version into the request body at `field` instead of sending `If-Match`. Updates always carry it; deletes carry it in a JSON `destroy_body` and fall back to `If-Match` without one. With `update_strategy = \"json_patch\"` it is sent as a leading `test` operation. Default: false.",
+				Optional:            true,
+				Validators: []validator.Bool{
+					boolvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("field")),
+				},
+			},
+		},
+	}
+}
+
+// lockingSource returns the configured source of the concurrency token
+func lockingSource(locking *OptimisticLockingModel) string {
+	if locking.Source.IsNull() || locking.Source.IsUnknown() {
+		return lockingSourceETag
+	}
+	return locking.Source.ValueString()
+}
+
+// validateOptimisticLocking checks settings the schema cannot express
+func validateOptimisticLocking(locking *OptimisticLockingModel) error {
+	if locking == nil || locking.Source.IsUnknown() || locking.Field.IsUnknown() {
+		return nil
+	}
+	if lockingSource(locking) == lockingSourceField && locking.Field.IsNull() {
+		return fmt.Errorf("optimistic_locking source \"field\" requires field")
+	}
+	if locking.SendInBody.ValueBool() && lockingSource(locking) != lockingSourceField {
+		return fmt.Errorf("optimistic_locking send_in_body requires source \"field\"")
+	}
+	return nil
+}
+
+// concurrencyToken extracts the object version from a response. A response
+// without one yields null, so a stale version is never sent.
+func concurrencyToken(locking *OptimisticLockingModel, response *client.Response) types.String {
+	if locking == nil {
+		return types.StringNull()
+	}
+
+	switch lockingSource(locking) {
+	case lockingSourceBodyHash:
+		if response.SHA256 == "" {
+			return types.StringNull()
+		}
+		return types.StringValue(response.SHA256)
+
+	case lockingSourceField:
+		document, err := decodeJSONDocument(response.Body)
+		if err != nil {
+			return types.StringNull()
+		}
+		value, found, err := lookupJSONPath(document, locking.Field.ValueString())
+		if err != nil || !found {
+			return types.StringNull()
+		}
+		token, ok := jsonScalarString(value)
+		if !ok {
+			return types.StringNull()
+		}
+		return types.StringValue(token)
+
+	default:
+		etag := http.Header(response.Headers).Get("ETag")
+		if etag == "" {
+			return types.StringNull()
+		}
+		return types.StringValue(etag)
+	}
+}
+
+// applyConcurrencyToken adds the stored object version to an update or delete
+// request. For body_hash the object is read first and compared instead, which
+// returns errObjectChanged on a mismatch. Objects without a recorded version are
+// sent unconditionally.
+func (r *RestResource) applyConcurrencyToken(ctx context.Context, data *RestResourceModel, options *client.RequestOptions, operation string) error {
+	locking := data.OptimisticLocking
+	if locking == nil || data.ConcurrencyToken.IsNull() || data.ConcurrencyToken.IsUnknown() {
+		return nil
+	}
+	token := data.ConcurrencyToken.ValueString()
+
+	switch {
+	case lockingSource(locking) == lockingSourceBodyHash:
+		return r.checkBodyHash(ctx, data, token)
+
+	case locking.SendInBody.ValueBool():
+		embedded, err := embedConcurrencyToken(options.Body, locking.Field.ValueString(), token, updateStrategy(data) == updateStrategyJSONPatch && operation == "update")
+		if err != nil && operation == "update" {
+			return fmt.Errorf("cannot add the object version to the request body: %w", err)
+		}
+		if err == nil {
+			options.Body = embedded
+			return nil
+		}
+	}
+
+	if options.Headers == nil {
+		options.Headers = make(map[string]string)
+	}
+	options.Headers["If-Match"] = token
+	return nil
+}
+
+// errObjectChanged reports a failed version check
+var errObjectChanged = fmt.Errorf("object changed outside Terraform")
+
+// checkBodyHash reads the object and compares its hash to the one recorded when
+// it was last read
+func (r *RestResource) checkBodyHash(ctx context.Context, data *RestResourceModel, token string) error {
+	response, decision, endpoint, err := r.readObject(ctx, data)
+	if err != nil {
+		return fmt.Errorf("reading the object to check for changes: %w", err)
+	}
+	if decision.outcome == statusGone {
+		return nil
+	}
+	if checkFailedStatus(ctx, response, decision) != nil {
+		return fmt.Errorf("reading %s to check for changes returned status %d: %s", endpoint, response.StatusCode, string(response.Body))
+	}
+	if response.SHA256 != token {
+		return errObjectChanged
+	}
+	return nil
+}
+
+// embedConcurrencyToken writes the version into a JSON request body at field.
+// Versions that look like numbers are written as numbers. JSON patch documents
+// get a leading test operation instead, so the patch only applies to that version.
+func embedConcurrencyToken(body []byte, field, token string, jsonPatch bool) ([]byte, error) {
+	var value interface{} = token
+	if isJSONNumber(token) {
+		value = json.Number(token)
+	}
+
+	document, err := decodeJSONDocument(body)
+	if err != nil {
+		return nil, fmt.Errorf("the body is not JSON: %w", err)
+	}
+
+	if jsonPatch {
+		operations, ok := document.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("the JSON patch is not an array")
+		}
+		pointer, err := jsonPointer(field)
+		if err != nil {
+			return nil, err
+		}
+		test := map[string]interface{}{"op": "test", "path": pointer, "value": value}
+		return json.Marshal(append([]interface{}{test}, operations...))
+	}
+
+	if _, ok := document.(map[string]interface{}); !ok {
+		return nil, fmt.Errorf("the body is not a JSON object")
+	}
+	if err := setJSONPath(document, field, value); err != nil {
+		return nil, err
+	}
+	return json.Marshal(document)
+}
+
+// isJSONNumber reports whether value is a JSON number literal
+func isJSONNumber(value string) bool {
+	decoded, err := decodeJSONDocument([]byte(value))
+	if err != nil {
+		return false
+	}
+	number, ok := decoded.(json.Number)
+	return ok && number.String() == value
+}
+
+// addPreconditionFailed records a version conflict detected by the API (412) or
+// by the body hash check
+func addPreconditionFailed(diags *diag.Diagnostics, method, endpoint string) {
+	diags.AddError(
+		"Object Changed Outside Terraform",
+		fmt.Sprintf("%s %s: %s", method, endpoint, preconditionFailedBody),
+	)
+}
@@ -0,0 +1,241 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-rest/internal/client"
+)
+
+func TestConcurrencyToken(t *testing.T) {
+	response := &client.Response{
+		StatusCode: 200,
+		Headers:    map[string][]string{"Etag": {`"v7"`}},
+		Body:       []byte(`{"metadata": {"resourceVersion": 12, "label": "x"}}`),
+		SHA256:     "abc123",
+	}
+
+	tests := []struct {
+		name     string
+		locking  *OptimisticLockingModel
+		expected types.String
+	}{
+		{
+			name:     "locking disabled",
+			expected: types.StringNull(),
+		},
+		{
+			name:     "etag by default",
+			locking:  &OptimisticLockingModel{Source: types.StringNull()},
+			expected: types.StringValue(`"v7"`),
+		},
+		{
+			name:     "numeric body field",
+			locking:  &OptimisticLockingModel{Source: types.StringValue("field"), Field: types.StringValue("metadata.resourceVersion")},
+			expected: types.StringValue("12"),
+		},
+		{
+			name:     "missing body field",
+			locking:  &OptimisticLockingModel{Source: types.StringValue("field"), Field: types.StringValue("/metadata/generation")},
+			expected: types.StringNull(),
+		},
+		{
+			name:     "body hash",
+			locking:  &OptimisticLockingModel{Source: types.StringValue("body_hash")},
+			expected: types.StringValue("abc123"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := concurrencyToken(tt.locking, response)
+			if !token.Equal(tt.expected) {
+				t.Errorf("Expected %s, got %s", tt.expected, token)
+			}
+		})
+	}
+}
+
+func TestEmbedConcurrencyToken(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		field     string
+		token     string
+		jsonPatch bool
+		expected  string
+		errMsg    string
+	}{
+		{
+			name:     "top-level string field",
+			body:     `{"name":"a"}`,
+			field:    "version",
+			token:    "v7",
+			expected: `{"name":"a","version":"v7"}`,
+		},
+		{
+			name:     "nested numeric field",
+			body:     `{"name":"a"}`,
+			field:    "metadata.resourceVersion",
+			token:    "12",
+			expected: `{"metadata":{"resourceVersion":12},"name":"a"}`,
+		},
+		{
+			name:      "json patch test operation",
+			body:      `[{"op":"replace","path":"/name","value":"b"}]`,
+			field:     "metadata.resourceVersion",
+			token:     "12",
+			jsonPatch: true,
+			expected:  `[{"op":"test","path":"/metadata/resourceVersion","value":12},{"op":"replace","path":"/name","value":"b"}]`,
+		},
+		{
+			name:   "non-object body",
+			body:   `["a"]`,
+			field:  "version",
+			token:  "1",
+			errMsg: "not a JSON object",
+		},
+		{
+			name:   "empty body",
+			body:   ``,
+			field:  "version",
+			token:  "1",
+			errMsg: "not JSON",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := embedConcurrencyToken([]byte(tt.body), tt.field, tt.token, tt.jsonPatch)
+
+			if tt.errMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+					t.Errorf("Expected error containing %q, got %v", tt.errMsg, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if string(result) != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, string(result))
+			}
+		})
+	}
+}
+
+func TestRestResource_ApplyConcurrencyToken(t *testing.T) {
+	current := `{"id":"42","name":"a"}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(current))
+	}))
+	defer server.Close()
+
+	restClient, err := client.NewRestClient(client.Config{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("Failed to create client: %s", err)
+	}
+	r := &RestResource{client: restClient}
+
+	digest := sha256.Sum256([]byte(current))
+	currentHash := hex.EncodeToString(digest[:])
+
+	tests := []struct {
+		name     string
+		locking  *OptimisticLockingModel
+		token    types.String
+		body     string
+		ifMatch  string
+		expected string
+		changed  bool
+	}{
+		{
+			name:    "etag sent as If-Match",
+			locking: &OptimisticLockingModel{Source: types.StringNull()},
+			token:   types.StringValue(`"v7"`),
+			body:    `{"name":"b"}`,
+			ifMatch: `"v7"`,
+		},
+		{
+			name:    "no recorded version",
+			locking: &OptimisticLockingModel{Source: types.StringNull()},
+			token:   types.StringNull(),
+			body:    `{"name":"b"}`,
+		},
+		{
+			name:     "field embedded in body",
+			locking:  &OptimisticLockingModel{Source: types.StringValue("field"), Field: types.StringValue("version"), SendInBody: types.BoolValue(true)},
+			token:    types.StringValue("3"),
+			body:     `{"name":"b"}`,
+			expected: `{"name":"b","version":3}`,
+		},
+		{
+			name:    "unchanged body hash",
+			locking: &OptimisticLockingModel{Source: types.StringValue("body_hash")},
+			token:   types.StringValue(currentHash),
+			body:    `{"name":"b"}`,
+		},
+		{
+			name:    "changed body hash",
+			locking: &OptimisticLockingModel{Source: types.StringValue("body_hash")},
+			token:   types.StringValue("stale"),
+			body:    `{"name":"b"}`,
+			changed: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := &RestResourceModel{
+				Endpoint:          types.StringValue("/items"),
+				Id:                types.StringValue("42"),
+				OptimisticLocking: tt.locking,
+				ConcurrencyToken:  tt.token,
+			}
+			options := client.RequestOptions{Method: "PUT", Endpoint: "/items/42", Body: []byte(tt.body)}
+
+			err := r.applyConcurrencyToken(context.Background(), data, &options, "update")
+
+			if tt.changed {
+				if !errors.Is(err, errObjectChanged) {
+					t.Errorf("Expected errObjectChanged, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			if options.Headers["If-Match"] != tt.ifMatch {
+				t.Errorf("Expected If-Match %q, got %q", tt.ifMatch, options.Headers["If-Match"])
+			}
+			expected := tt.expected
+			if expected == "" {
+				expected = tt.body
+			}
+			if string(options.Body) != expected {
+				t.Errorf("Expected body %s, got %s", expected, string(options.Body))
+			}
+		})
+	}
+}
+
+func TestValidateOptimisticLocking(t *testing.T) {
+	if err := validateOptimisticLocking(&OptimisticLockingModel{Source: types.StringValue("field"), Field: types.StringNull()}); err == nil {
+		t.Errorf("Expected source field without field to be rejected")
+	}
+	if err := validateOptimisticLocking(&OptimisticLockingModel{Source: types.StringNull(), Field: types.StringValue("version"), SendInBody: types.BoolValue(true)}); err == nil {
+		t.Errorf("Expected send_in_body with etag to be rejected")
+	}
+	if err := validateOptimisticLocking(&OptimisticLockingModel{Source: types.StringValue("field"), Field: types.StringValue("version"), SendInBody: types.BoolValue(true)}); err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"terraform-provider-rest/internal/client"
+)
+
+const (
+	createConflictFail   = "fail"
+	createConflictAdopt  = "adopt"
+	createConflictUpdate = "update"
+)
+
+// createConflictMode returns the configured on_create_conflict behaviour
+func createConflictMode(data *RestResourceModel) string {
+	if data.OnCreateConflict.IsNull() || data.OnCreateConflict.IsUnknown() {
+		return createConflictFail
+	}
+	return data.OnCreateConflict.ValueString()
+}
+
+// findExistingObject reads the object a create would produce, returning nil when
+// it does not exist. The object must be addressable before it is created, so the
+// read path cannot depend on the ID the API assigns.
+func (r *RestResource) findExistingObject(ctx context.Context, data *RestResourceModel) (*client.Response, error) {
+	response, decision, endpoint, err := r.readObject(ctx, data)
+	if err != nil {
+		return nil, fmt.Errorf("cannot look up the existing object: %w", err)
+	}
+
+	// A soft-deleted object does not count as existing
+	outcome, err := r.readGone(ctx, data, response, decision)
+	if err != nil {
+		return nil, err
+	}
+	switch outcome {
+	case statusGone:
+		return nil, nil
+	case statusFailed:
+		return nil, fmt.Errorf("looking up the existing object at %s returned status %d: %s", endpoint, response.StatusCode, string(response.Body))
+	}
+
+	tflog.Info(ctx, "found existing object", map[string]interface{}{
+		"endpoint":           endpoint,
+		"on_create_conflict": createConflictMode(data),
+	})
+	return response, nil
+}
+
+// takeOverExistingObject records an object that already exists in the model and,
+// with on_create_conflict = "update", updates it to match the configuration
+func (r *RestResource) takeOverExistingObject(ctx context.Context, data *RestResourceModel, existing *client.Response, diags *diag.Diagnostics) {
+	if err := r.processResponse(ctx, existing, data); err != nil {
+		diags.AddError("Response Processing Error", err.Error())
+		return
+	}
+	if createConflictMode(data) != createConflictUpdate {
+		return
+	}
+
+	prior, err := r.existingBody(ctx, data, existing)
+	if err != nil {
+		diags.AddError("Unable to Compute Patch", err.Error())
+		return
+	}
+	r.sendUpdate(ctx, data, prior, diags)
+}
+
+// existingBody returns the live values of the fields the configured body sets,
+// so patches computed against it leave other server-side fields alone
+func (r *RestResource) existingBody(ctx context.Context, data *RestResourceModel, existing *client.Response) (JSONBody, error) {
+	planned := updateDocument(data)
+	if updateStrategy(data) == updateStrategyPutFull || planned.IsNull() {
+		return NewJSONBodyNull(), nil
+	}
+
+	var expected, current interface{}
+	if err := json.Unmarshal([]byte(planned.ValueString()), &expected); err != nil {
+		return NewJSONBodyNull(), fmt.Errorf("update_strategy %q requires a JSON body: %w", updateStrategy(data), err)
+	}
+	if err := json.Unmarshal(existing.Body, &current); err != nil {
+		return NewJSONBodyNull(), fmt.Errorf("the existing object is not JSON: %w", err)
+	}
+
+	comparator, err := r.driftComparator(ctx, data)
+	if err != nil {
+		return NewJSONBodyNull(), err
+	}
+	projected, err := json.Marshal(comparator.project(expected, current))
+	if err != nil {
+		return NewJSONBodyNull(), err
+	}
+	return NewJSONBodyValue(string(projected)), nil
+}
+
+// createFromExisting finishes a create that found the object already existing,
+// adopting or updating it as on_create_conflict allows
+func (r *RestResource) createFromExisting(ctx context.Context, data *RestResourceModel, existing *client.Response, resp *resource.CreateResponse) {
+	if createConflictMode(data) == createConflictFail {
+		endpoint, _ := r.operationPath(data, "read")
+		resp.Diagnostics.AddError(
+			"Object Already Exists",
+			fmt.Sprintf("An object already exists at %s. Set on_create_conflict to \"adopt\" or \"update\" to manage it with this resource, or import it.", endpoint),
+		)
+		return
+	}
+
+	r.takeOverExistingObject(ctx, data, existing, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Wait for the object to become usable. It is managed from here on, so
+	// save it even if waiting fails and let Terraform taint it
+	if data.WaitForReady != nil {
+		if err := r.waitForReady(ctx, data); err != nil {
+			resp.Diagnostics.AddError("Resource Not Ready", err.Error())
+			resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+			return
+		}
+	}
+
+	tflog.Trace(ctx, "adopted existing REST resource", map[string]interface{}{
+		"endpoint":           data.Endpoint.ValueString(),
+		"on_create_conflict": createConflictMode(data),
+		"id":                 data.Id.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
@@ -0,0 +1,149 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-rest/internal/client"
+)
+
+func TestRestResource_FindExistingObject(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		found  bool
+		errMsg string
+	}{
+		{name: "exists", status: http.StatusOK, found: true},
+		{name: "not found", status: http.StatusNotFound},
+		{name: "gone", status: http.StatusGone},
+		{name: "forbidden", status: http.StatusForbidden, errMsg: "returned status 403"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/items/widget" {
+					t.Errorf("Unexpected lookup path %s", r.URL.Path)
+				}
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(`{"id": "7", "name": "widget"}`))
+			}))
+			defer server.Close()
+
+			restClient, err := client.NewRestClient(client.Config{BaseURL: server.URL + "/api"})
+			if err != nil {
+				t.Fatalf("Failed to create client: %s", err)
+			}
+			r := &RestResource{client: restClient}
+			data := &RestResourceModel{
+				Endpoint: types.StringValue("/items"),
+				Name:     types.StringValue("widget"),
+				Id:       types.StringUnknown(),
+				SelfLink: types.StringNull(),
+			}
+
+			existing, err := r.findExistingObject(context.Background(), data)
+
+			if tt.errMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+					t.Errorf("Expected error containing %q, got %v", tt.errMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if (existing != nil) != tt.found {
+				t.Errorf("Expected found=%v, got %v", tt.found, existing != nil)
+			}
+		})
+	}
+}
+
+func TestRestResource_TakeOverExistingObject(t *testing.T) {
+	existingBody := `{"id": "7", "name": "widget", "size": 1, "color": "red", "created_at": "2024-01-01"}`
+
+	tests := []struct {
+		name          string
+		mode          types.String
+		strategy      types.String
+		expectMethod  string
+		expectRequest string
+	}{
+		{
+			name:     "adopt only reads",
+			mode:     types.StringValue(createConflictAdopt),
+			strategy: types.StringNull(),
+		},
+		{
+			name:          "update sends the full body",
+			mode:          types.StringValue(createConflictUpdate),
+			strategy:      types.StringNull(),
+			expectMethod:  "PUT",
+			expectRequest: `{"name":"widget","size":2}`,
+		},
+		{
+			name:          "update patches only configured fields",
+			mode:          types.StringValue(createConflictUpdate),
+			strategy:      types.StringValue(updateStrategyMergePatch),
+			expectMethod:  "PATCH",
+			expectRequest: `{"size":2}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var method, request string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet {
+					method = r.Method
+					body, _ := io.ReadAll(r.Body)
+					request = string(body)
+				}
+				_, _ = w.Write([]byte(`{"id": "7", "name": "widget", "size": 2}`))
+			}))
+			defer server.Close()
+
+			restClient, err := client.NewRestClient(client.Config{BaseURL: server.URL})
+			if err != nil {
+				t.Fatalf("Failed to create client: %s", err)
+			}
+			r := &RestResource{client: restClient}
+			data := &RestResourceModel{
+				Endpoint:            types.StringValue("/items"),
+				Name:                types.StringValue("widget"),
+				Id:                  types.StringUnknown(),
+				SelfLink:            types.StringNull(),
+				Body:                NewJSONBodyValue(`{"name":"widget","size":2}`),
+				OnCreateConflict:    tt.mode,
+				UpdateStrategy:      tt.strategy,
+				UpdateMethod:        types.StringUnknown(),
+				IgnoreFields:        types.ListNull(types.StringType),
+				RedactResponsePaths: types.ListNull(types.StringType),
+			}
+			var diags diag.Diagnostics
+
+			r.takeOverExistingObject(context.Background(), data, &client.Response{StatusCode: 200, Body: []byte(existingBody)}, &diags)
+
+			if diags.HasError() {
+				t.Fatalf("Unexpected errors: %v", diags)
+			}
+			if data.Id.ValueString() != "7" {
+				t.Errorf("Expected the existing object's ID, got %s", data.Id)
+			}
+			if method != tt.expectMethod {
+				t.Errorf("Expected update method %q, got %q", tt.expectMethod, method)
+			}
+			if request != tt.expectRequest {
+				t.Errorf("Expected update body %s, got %s", tt.expectRequest, request)
+			}
+		})
+	}
+}
@@ -0,0 +1,470 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// defaultIgnoreFields are commonly server-managed fields that are ignored during
+// drift detection unless disable_default_ignore_fields is set
+var defaultIgnoreFields = []string{
+	"id", "created_at", "updated_at", "last_modified", "etag",
+	"version", "revision", "timestamp", "last_updated_at",
+	"created_by", "updated_by", "modified_by", "owner_id",
+	"_id", "_created", "_updated", "_modified", "_version",
+	"createdAt", "updatedAt", "lastModified", "lastUpdated",
+	"href", "self", "links", "_links", "meta", "_meta",
+}
+
+// driftReportAttrTypes describes one entry of the drift_report attribute
+var driftReportAttrTypes = map[string]attr.Type{
+	"path":     types.StringType,
+	"expected": types.StringType,
+	"actual":   types.StringType,
+}
+
+// driftPathElement is one step of the location of a value being compared
+type driftPathElement struct {
+	key     string
+	index   int
+	isIndex bool
+	// label replaces the index in reports for arrays matched by key, e.g. "id=alice"
+	label string
+}
+
+// driftEntry records one drifted value. missing marks configured values absent
+// from the live object, added marks live array elements that are not configured.
+type driftEntry struct {
+	path     string
+	expected interface{}
+	actual   interface{}
+	missing  bool
+	added    bool
+}
+
+// arrayKeyRule compares the arrays matching path by the value of field instead
+// of by position
+type arrayKeyRule struct {
+	path  []pathSegment
+	field string
+}
+
+// driftComparator compares a configured body with a live response according to
+// the ignore and array key rules of a resource
+type driftComparator struct {
+	// ignoreNames are plain field names ignored at any depth
+	ignoreNames map[string]bool
+	// ignorePaths are path rules, optionally with wildcards
+	ignorePaths [][]pathSegment
+	arrayKeys   []arrayKeyRule
+	// ignoreMissing skips configured keys that the live object does not have
+	ignoreMissing bool
+}
+
+// newDriftComparator builds a comparator from ignore rules and array key rules.
+// Rules without path syntax are plain names matched at any depth, as before.
+func newDriftComparator(ignoreRules []string, arrayKeys map[string]string) (*driftComparator, error) {
+	comparator := &driftComparator{ignoreNames: make(map[string]bool)}
+
+	for _, rule := range ignoreRules {
+		if !isPathRule(rule) {
+			comparator.ignoreNames[rule] = true
+			continue
+		}
+		segments, err := parsePathPattern(rule)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ignore_fields entry %q: %w", rule, err)
+		}
+		comparator.ignorePaths = append(comparator.ignorePaths, segments)
+	}
+
+	for rule, field := range arrayKeys {
+		segments, err := parsePathPattern(rule)
+		if err != nil {
+			return nil, fmt.Errorf("invalid array_key_fields entry %q: %w", rule, err)
+		}
+		comparator.arrayKeys = append(comparator.arrayKeys, arrayKeyRule{path: segments, field: field})
+	}
+
+	return comparator, nil
+}
+
+// isPathRule reports whether an ignore rule uses path syntax rather than being a
+// plain field name
+func isPathRule(rule string) bool {
+	return strings.ContainsAny(rule, "./[*") || strings.HasPrefix(rule, "$")
+}
+
+// parsePathPattern parses a dotted path or JSON pointer pattern. A "*" pointer
+// token is treated as a wildcard like in dotted paths.
+func parsePathPattern(rule string) ([]pathSegment, error) {
+	segments, err := parseJSONPath(rule)
+	if err != nil {
+		return nil, err
+	}
+	for i := range segments {
+		if segments[i].key == "*" {
+			segments[i].wildcard = true
+		}
+	}
+	return segments, nil
+}
+
+// matchesPath reports whether a rule matches a concrete location
+func matchesPath(rule []pathSegment, location []driftPathElement) bool {
+	if len(rule) != len(location) {
+		return false
+	}
+	for i, segment := range rule {
+		element := location[i]
+		switch {
+		case segment.wildcard:
+			continue
+		case segment.isIndex:
+			if !element.isIndex || element.index != segment.index {
+				return false
+			}
+		case element.isIndex:
+			if segment.key != strconv.Itoa(element.index) {
+				return false
+			}
+		default:
+			if segment.key != element.key {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// ignored reports whether the value at location is excluded from comparison
+func (c *driftComparator) ignored(location []driftPathElement) bool {
+	if len(location) > 0 {
+		last := location[len(location)-1]
+		if !last.isIndex && c.ignoreNames[last.key] {
+			return true
+		}
+	}
+	for _, rule := range c.ignorePaths {
+		if matchesPath(rule, location) {
+			return true
+		}
+	}
+	return false
+}
+
+// arrayKey returns the key field for the array at location, if any
+func (c *driftComparator) arrayKey(location []driftPathElement) (string, bool) {
+	for _, rule := range c.arrayKeys {
+		if matchesPath(rule.path, location) {
+			return rule.field, true
+		}
+	}
+	return "", false
+}
+
+// compare returns every drifted value between expected and current, sorted by path
+func (c *driftComparator) compare(expected, current interface{}) []driftEntry {
+	var entries []driftEntry
+	c.compareValue(expected, current, nil, &entries)
+	sort.Slice(entries, func(i, j int) bool { return entries[i].path < entries[j].path })
+	return entries
+}
+
+func (c *driftComparator) compareValue(expected, current interface{}, location []driftPathElement, entries *[]driftEntry) {
+	switch expectedValue := expected.(type) {
+	case map[string]interface{}:
+		currentMap, ok := current.(map[string]interface{})
+		if !ok {
+			break
+		}
+		for key, value := range expectedValue {
+			child := appendPath(location, driftPathElement{key: key})
+			if c.ignored(child) {
+				continue
+			}
+			currentValue, exists := currentMap[key]
+			if !exists && c.ignoreMissing {
+				continue
+			}
+			if !exists {
+				*entries = append(*entries, driftEntry{path: formatDriftPath(child), expected: value, missing: true})
+				continue
+			}
+			c.compareValue(value, currentValue, child, entries)
+		}
+		return
+
+	case []interface{}:
+		currentArray, ok := current.([]interface{})
+		if !ok {
+			break
+		}
+		if field, keyed := c.arrayKey(location); keyed {
+			c.compareKeyedArray(expectedValue, currentArray, field, location, entries)
+			return
+		}
+		if len(expectedValue) != len(currentArray) {
+			break
+		}
+		for i := range expectedValue {
+			child := appendPath(location, driftPathElement{index: i, isIndex: true})
+			if c.ignored(child) {
+				continue
+			}
+			c.compareValue(expectedValue[i], currentArray[i], child, entries)
+		}
+		return
+
+	default:
+		if jsonValuesEqual(expected, current) {
+			return
+		}
+	}
+
+	*entries = append(*entries, driftEntry{path: formatDriftPath(location), expected: expected, actual: current})
+}
+
+// compareKeyedArray matches array elements by the value of a key field, so the
+// order of elements does not matter. Elements added or removed are drift.
+func (c *driftComparator) compareKeyedArray(expected, current []interface{}, field string, location []driftPathElement, entries *[]driftEntry) {
+	currentByKey := make(map[string]interface{}, len(current))
+	for _, element := range current {
+		if key, ok := elementKey(element, field); ok {
+			currentByKey[key] = element
+		}
+	}
+
+	seen := make(map[string]bool, len(expected))
+	for i, element := range expected {
+		child := appendPath(location, driftPathElement{index: i, isIndex: true})
+		key, ok := elementKey(element, field)
+		if ok {
+			seen[key] = true
+			child[len(child)-1].label = field + "=" + key
+		}
+		if c.ignored(child) {
+			continue
+		}
+		currentElement, exists := currentByKey[key]
+		if !ok || !exists {
+			*entries = append(*entries, driftEntry{path: formatDriftPath(child), expected: element, missing: true})
+			continue
+		}
+		c.compareValue(element, currentElement, child, entries)
+	}
+
+	for _, element := range current {
+		key, ok := elementKey(element, field)
+		if !ok || seen[key] {
+			continue
+		}
+		child := appendPath(location, driftPathElement{index: len(expected), isIndex: true, label: field + "=" + key})
+		*entries = append(*entries, driftEntry{path: formatDriftPath(child), actual: element, added: true})
+	}
+}
+
+// project returns the live values for the locations present in expected.
+// Ignored values keep their configured value and values missing from the
+// response are left out, unless ignoreMissing keeps them too, so the projection
+// differs from expected exactly where the object has drifted.
+func (c *driftComparator) project(expected, current interface{}) interface{} {
+	return c.projectValue(expected, current, nil)
+}
+
+func (c *driftComparator) projectValue(expected, current interface{}, location []driftPathElement) interface{} {
+	switch expectedValue := expected.(type) {
+	case map[string]interface{}:
+		currentMap, ok := current.(map[string]interface{})
+		if !ok {
+			return current
+		}
+		projected := make(map[string]interface{}, len(expectedValue))
+		for key, value := range expectedValue {
+			child := appendPath(location, driftPathElement{key: key})
+			currentValue, exists := currentMap[key]
+			if c.ignored(child) || (!exists && c.ignoreMissing) {
+				projected[key] = value
+				continue
+			}
+			if exists {
+				projected[key] = c.projectValue(value, currentValue, child)
+			}
+		}
+		return projected
+
+	case []interface{}:
+		currentArray, ok := current.([]interface{})
+		if !ok {
+			return current
+		}
+		if field, keyed := c.arrayKey(location); keyed {
+			return c.projectKeyedArray(expectedValue, currentArray, field, location)
+		}
+		if len(expectedValue) != len(currentArray) {
+			return current
+		}
+		projected := make([]interface{}, len(currentArray))
+		for i := range currentArray {
+			child := appendPath(location, driftPathElement{index: i, isIndex: true})
+			if c.ignored(child) {
+				projected[i] = expectedValue[i]
+				continue
+			}
+			projected[i] = c.projectValue(expectedValue[i], currentArray[i], child)
+		}
+		return projected
+	}
+
+	return current
+}
+
+// projectKeyedArray keeps the configured order for elements that still exist,
+// then appends elements that only exist in the live object
+func (c *driftComparator) projectKeyedArray(expected, current []interface{}, field string, location []driftPathElement) []interface{} {
+	currentByKey := make(map[string]interface{}, len(current))
+	for _, element := range current {
+		if key, ok := elementKey(element, field); ok {
+			currentByKey[key] = element
+		}
+	}
+
+	projected := make([]interface{}, 0, len(current))
+	seen := make(map[string]bool, len(expected))
+	for i, element := range expected {
+		key, ok := elementKey(element, field)
+		if !ok {
+			continue
+		}
+		currentElement, exists := currentByKey[key]
+		if !exists {
+			continue
+		}
+		seen[key] = true
+		child := appendPath(location, driftPathElement{index: i, isIndex: true})
+		projected = append(projected, c.projectValue(element, currentElement, child))
+	}
+	for _, element := range current {
+		if key, ok := elementKey(element, field); !ok || !seen[key] {
+			projected = append(projected, element)
+		}
+	}
+	return projected
+}
+
+// elementKey returns the key field of an array element as a string
+func elementKey(element interface{}, field string) (string, bool) {
+	object, ok := element.(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+	value, ok := object[field]
+	if !ok {
+		return "", false
+	}
+	return jsonScalarString(value)
+}
+
+// appendPath returns a copy of location extended by element, so sibling paths
+// never share a backing array
+func appendPath(location []driftPathElement, element driftPathElement) []driftPathElement {
+	child := make([]driftPathElement, len(location), len(location)+1)
+	copy(child, location)
+	return append(child, element)
+}
+
+// formatDriftPath renders a location as a dotted path, e.g. "members[id=a].role"
+func formatDriftPath(location []driftPathElement) string {
+	if len(location) == 0 {
+		return "$"
+	}
+	var builder strings.Builder
+	for i, element := range location {
+		switch {
+		case element.label != "":
+			builder.WriteString("[" + element.label + "]")
+		case element.isIndex:
+			builder.WriteString("[" + strconv.Itoa(element.index) + "]")
+		default:
+			if i > 0 {
+				builder.WriteString(".")
+			}
+			builder.WriteString(element.key)
+		}
+	}
+	return builder.String()
+}
+
+// jsonValuesEqual compares two decoded JSON scalars. Numbers decoded as float64
+// compare numerically.
+func jsonValuesEqual(a, b interface{}) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	switch aValue := a.(type) {
+	case float64:
+		bValue, ok := b.(float64)
+		return ok && aValue == bValue
+	case string:
+		bValue, ok := b.(string)
+		return ok && aValue == bValue
+	case bool:
+		bValue, ok := b.(bool)
+		return ok && aValue == bValue
+	}
+	return false
+}
+
+// driftReportValue converts drift entries into the drift_report attribute value
+func driftReportValue(ctx context.Context, entries []driftEntry) types.List {
+	elementType := types.ObjectType{AttrTypes: driftReportAttrTypes}
+	elements := make([]attr.Value, 0, len(entries))
+
+	for _, entry := range entries {
+		actual := types.StringNull()
+		if !entry.missing {
+			actual = types.StringValue(string(mustMarshalJSON(entry.actual)))
+		}
+		expected := types.StringNull()
+		if !entry.added {
+			expected = types.StringValue(string(mustMarshalJSON(entry.expected)))
+		}
+
+		element, diags := types.ObjectValue(driftReportAttrTypes, map[string]attr.Value{
+			"path":     types.StringValue(entry.path),
+			"expected": expected,
+			"actual":   actual,
+		})
+		if diags.HasError() {
+			tflog.Warn(ctx, "failed to create drift report entry", map[string]interface{}{
+				"errors": diags.Errors(),
+			})
+			continue
+		}
+		elements = append(elements, element)
+	}
+
+	report, diags := types.ListValue(elementType, elements)
+	if diags.HasError() {
+		return types.ListNull(elementType)
+	}
+	return report
+}
+
+// driftPaths lists the paths of drift entries for messages
+func driftPaths(entries []driftEntry) []string {
+	paths := make([]string, len(entries))
+	for i, entry := range entries {
+		paths[i] = entry.path
+	}
+	return paths
+}
@@ -0,0 +1,192 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-rest/internal/client"
+)
+
+func decodeTestJSON(t *testing.T, document string) interface{} {
+	t.Helper()
+	var value interface{}
+	if err := json.Unmarshal([]byte(document), &value); err != nil {
+		t.Fatalf("Invalid test JSON %s: %s", document, err)
+	}
+	return value
+}
+
+func TestDriftComparator_Compare(t *testing.T) {
+	tests := []struct {
+		name      string
+		ignore    []string
+		arrayKeys map[string]string
+		expected  string
+		current   string
+		drifted   []string
+	}{
+		{
+			name:     "plain name ignored at any depth",
+			ignore:   []string{"version"},
+			expected: `{"version": 1, "metadata": {"version": 1}}`,
+			current:  `{"version": 2, "metadata": {"version": 2}}`,
+		},
+		{
+			name:     "dotted path only ignores that location",
+			ignore:   []string{"metadata.version"},
+			expected: `{"version": 1, "metadata": {"version": 1}}`,
+			current:  `{"version": 2, "metadata": {"version": 2}}`,
+			drifted:  []string{"version"},
+		},
+		{
+			name:     "json pointer",
+			ignore:   []string{"/metadata/version"},
+			expected: `{"version": 1, "metadata": {"version": 1}}`,
+			current:  `{"version": 2, "metadata": {"version": 2}}`,
+			drifted:  []string{"version"},
+		},
+		{
+			name:     "object wildcard",
+			ignore:   []string{"labels.*"},
+			expected: `{"labels": {"a": "1", "b": "2"}, "name": "x"}`,
+			current:  `{"labels": {"a": "9", "b": "9"}, "name": "x"}`,
+		},
+		{
+			name:     "array wildcard",
+			ignore:   []string{"rules[*].hits"},
+			expected: `{"rules": [{"port": 80, "hits": 0}, {"port": 443, "hits": 0}]}`,
+			current:  `{"rules": [{"port": 80, "hits": 5}, {"port": 8443, "hits": 7}]}`,
+			drifted:  []string{"rules[1].port"},
+		},
+		{
+			name:     "array length change",
+			expected: `{"tags": ["a", "b"]}`,
+			current:  `{"tags": ["a"]}`,
+			drifted:  []string{"tags"},
+		},
+		{
+			name:     "array order matters without key",
+			expected: `{"members": [{"id": "a"}, {"id": "b"}]}`,
+			current:  `{"members": [{"id": "b"}, {"id": "a"}]}`,
+			drifted:  []string{"members[0].id", "members[1].id"},
+		},
+		{
+			name:      "keyed array ignores order",
+			arrayKeys: map[string]string{"members": "id"},
+			expected:  `{"members": [{"id": "a", "role": "admin"}, {"id": "b", "role": "user"}]}`,
+			current:   `{"members": [{"id": "b", "role": "user"}, {"id": "a", "role": "admin"}]}`,
+		},
+		{
+			name:      "keyed array reports changed, removed and added elements",
+			ignore:    []string{"joined"},
+			arrayKeys: map[string]string{"members": "id"},
+			expected:  `{"members": [{"id": "a", "role": "admin"}, {"id": "b", "role": "user"}]}`,
+			current:   `{"members": [{"id": "c", "role": "user"}, {"id": "a", "role": "user", "joined": "today"}]}`,
+			drifted:   []string{"members[id=a].role", "members[id=b]", "members[id=c]"},
+		},
+		{
+			name:      "keyed array under wildcard path",
+			arrayKeys: map[string]string{"groups[*].members": "name"},
+			expected:  `{"groups": [{"members": [{"name": "x"}, {"name": "y"}]}]}`,
+			current:   `{"groups": [{"members": [{"name": "y"}, {"name": "x"}]}]}`,
+		},
+		{
+			name:     "type change",
+			expected: `{"config": {"a": 1}}`,
+			current:  `{"config": "a=1"}`,
+			drifted:  []string{"config"},
+		},
+		{
+			name:     "missing key",
+			expected: `{"name": "x", "size": 1}`,
+			current:  `{"name": "x"}`,
+			drifted:  []string{"size"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			comparator, err := newDriftComparator(tt.ignore, tt.arrayKeys)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			drifted := driftPaths(comparator.compare(decodeTestJSON(t, tt.expected), decodeTestJSON(t, tt.current)))
+			if len(drifted) == 0 && len(tt.drifted) == 0 {
+				return
+			}
+			if !reflect.DeepEqual(drifted, tt.drifted) {
+				t.Errorf("Expected drift at %v, got %v", tt.drifted, drifted)
+			}
+		})
+	}
+}
+
+func TestDriftComparator_Project(t *testing.T) {
+	comparator, err := newDriftComparator([]string{"rules[*].hits"}, map[string]string{"members": "id"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	expected := decodeTestJSON(t, `{"members": [{"id": "a", "role": "admin"}, {"id": "b", "role": "user"}], "rules": [{"port": 80, "hits": 0}]}`)
+	current := decodeTestJSON(t, `{"members": [{"id": "c", "role": "user"}, {"id": "a", "role": "user"}], "rules": [{"port": 81, "hits": 9}], "extra": true}`)
+
+	projected, err := json.Marshal(comparator.project(expected, current))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	want := `{"members":[{"id":"a","role":"user"},{"id":"c","role":"user"}],"rules":[{"hits":0,"port":81}]}`
+	if string(projected) != want {
+		t.Errorf("Expected %s, got %s", want, projected)
+	}
+}
+
+func TestDriftComparator_InvalidRule(t *testing.T) {
+	if _, err := newDriftComparator([]string{"items[x]"}, nil); err == nil {
+		t.Errorf("Expected an error for an invalid ignore rule")
+	}
+}
+
+func TestRestResource_DriftReport(t *testing.T) {
+	r := &RestResource{}
+	data := &RestResourceModel{
+		Id:                         types.StringValue("1"),
+		Endpoint:                   types.StringValue("/items"),
+		Body:                       NewJSONBodyValue(`{"name":"x","version":1,"tags":["a"]}`),
+		DriftAction:                types.StringValue("warn"),
+		DisableDefaultIgnoreFields: types.BoolValue(true),
+		IgnoreFields:               types.ListNull(types.StringType),
+		ArrayKeyFields:             map[string]types.String{},
+	}
+	var diags diag.Diagnostics
+
+	response := &client.Response{StatusCode: 200, Body: []byte(`{"name":"x","version":2}`)}
+	if err := r.performDriftDetection(context.Background(), data, response, &diags); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	type reportEntry struct {
+		Path     types.String `tfsdk:"path"`
+		Expected types.String `tfsdk:"expected"`
+		Actual   types.String `tfsdk:"actual"`
+	}
+	var report []reportEntry
+	if d := data.DriftReport.ElementsAs(context.Background(), &report, false); d.HasError() {
+		t.Fatalf("Failed to read drift report: %v", d)
+	}
+
+	if len(report) != 2 {
+		t.Fatalf("Expected 2 drift report entries, got %v", report)
+	}
+	if report[0].Path.ValueString() != "tags" || report[0].Expected.ValueString() != `["a"]` || !report[0].Actual.IsNull() {
+		t.Errorf("Unexpected entry for missing tags: %v", report[0])
+	}
+	if report[1].Path.ValueString() != "version" || report[1].Expected.ValueString() != "1" || report[1].Actual.ValueString() != "2" {
+		t.Errorf("Unexpected entry for version: %v", report[1])
+	}
+}
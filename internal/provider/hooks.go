@@ -0,0 +1,236 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"terraform-provider-rest/internal/client"
+)
+
+// hookResponseAttrTypes describes one entry of the computed hook_responses map
+var hookResponseAttrTypes = map[string]attr.Type{
+	"status_code": types.Int64Type,
+	"body":        types.StringType,
+}
+
+// HookModel describes an extra request sent before or after a create, update
+// or delete.
+type HookModel struct {
+	Method         types.String   `tfsdk:"method"`
+	Path           types.String   `tfsdk:"path"`
+	Body           types.String   `tfsdk:"body"`
+	ExpectedStatus []types.String `tfsdk:"expected_status"`
+	Poll           *PollModel     `tfsdk:"poll"`
+}
+
+// hookAttribute returns the schema for a lifecycle hook block
+func hookAttribute(description string) schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: description + " The request is sent with the resource's headers, query parameters and the provider's authentication.",
+		Optional:            true,
+		Attributes: map[string]schema.Attribute{
+			"method": schema.StringAttribute{
+				MarkdownDescription: "HTTP method of the request. Default: `POST`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("GET", "POST", "PUT", "PATCH", "DELETE"),
+				},
+			},
+			"path": schema.StringAttribute{
+				MarkdownDescription: "Path template of the request, e.g. `/items/{id}/activate`, with the same placeholders as `object_path`. `{id}` and response fields are not available before create.",
+				Required:            true,
+			},
+			"body": schema.StringAttribute{
+				MarkdownDescription: "Request body.",
+				Optional:            true,
+			},
+			"expected_status": schema.ListAttribute{
+				MarkdownDescription: "Statuses that mean the request succeeded, as codes (`204`), classes (`2xx`) or ranges (`200-204`). Default: `[\"2xx\"]`.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(stringvalidator.RegexMatches(statusPattern, "must be a status code like 204, a class like 2xx or a range like 200-204")),
+				},
+			},
+			"poll": pollAttribute(),
+		},
+	}
+}
+
+// hookResponsesAttribute returns the schema for the computed hook_responses map
+func hookResponsesAttribute() schema.MapNestedAttribute {
+	return schema.MapNestedAttribute{
+		MarkdownDescription: "Responses to the most recent create and update hooks, keyed by hook name, e.g. `hook_responses[\"post_create\"].body`. Delete hooks run after the resource leaves state and are not recorded.",
+		Computed:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"status_code": schema.Int64Attribute{
+					MarkdownDescription: "HTTP status code of the hook response.",
+					Computed:            true,
+				},
+				"body": schema.StringAttribute{
+					MarkdownDescription: "Body of the hook response, after `redact_response_paths`. Null when `sensitive_response` is enabled.",
+					Computed:            true,
+				},
+			},
+		},
+	}
+}
+
+// lifecycleHook returns the configured hook of the given name, or nil
+func lifecycleHook(data *RestResourceModel, name string) *HookModel {
+	switch name {
+	case "pre_create":
+		return data.PreCreate
+	case "post_create":
+		return data.PostCreate
+	case "pre_update":
+		return data.PreUpdate
+	case "post_update":
+		return data.PostUpdate
+	case "pre_delete":
+		return data.PreDelete
+	case "post_delete":
+		return data.PostDelete
+	}
+	return nil
+}
+
+// runHook sends the named lifecycle hook request, if configured, follows a
+// long-running operation it starts and records the response in hook_responses.
+// A hook that runs before an update or delete changes the object itself, so
+// with optimistic_locking it carries the version check instead of the request
+// that follows it.
+func (r *RestResource) runHook(ctx context.Context, data *RestResourceModel, name string, diags *diag.Diagnostics) {
+	hook := lifecycleHook(data, name)
+	if hook == nil {
+		return
+	}
+
+	endpoint, err := expandPathTemplate(hook.Path.ValueString(), r.pathTemplateValues(data))
+	if err != nil {
+		diags.AddError("Invalid Object Path", fmt.Sprintf("%s: %s", name, err))
+		return
+	}
+	method := "POST"
+	if !hook.Method.IsNull() && !hook.Method.IsUnknown() {
+		method = hook.Method.ValueString()
+	}
+
+	options := r.buildRequestOptions(ctx, data, method, hook.Body.ValueString())
+	options.Endpoint = endpoint
+	// Hook responses are small status documents, never the object itself
+	options.StreamResponse = false
+	options.SpoolPath = ""
+
+	versioned := data.OptimisticLocking != nil && (name == "pre_update" || name == "pre_delete")
+	if versioned {
+		if err := r.applyConcurrencyToken(ctx, data, &options, name); err != nil {
+			if errors.Is(err, errObjectChanged) {
+				addPreconditionFailed(diags, method, endpoint)
+			} else {
+				diags.AddError("Optimistic Locking Error", err.Error())
+			}
+			return
+		}
+	}
+
+	expected := []statusRange{{200, 299}}
+	if len(hook.ExpectedStatus) > 0 {
+		if expected, err = parseStatusRanges(hook.ExpectedStatus); err != nil {
+			diags.AddError("Invalid Status Policy", fmt.Sprintf("%s: %s", name, err))
+			return
+		}
+	}
+
+	tflog.Debug(ctx, "running lifecycle hook", map[string]interface{}{
+		"hook":     name,
+		"method":   method,
+		"endpoint": endpoint,
+	})
+
+	response, err := r.client.Do(ctx, options)
+	if err != nil {
+		addRequestError(diags, err, method, endpoint)
+		return
+	}
+	if versioned && response.StatusCode == http.StatusPreconditionFailed {
+		addPreconditionFailed(diags, method, endpoint)
+		return
+	}
+	if !statusIn(response.StatusCode, expected) {
+		diags.AddError("Lifecycle Hook Failed", fmt.Sprintf("%s %s %s returned status %d: %s", name, method, endpoint, response.StatusCode, string(response.Body)))
+		return
+	}
+
+	if hook.Poll != nil && response.StatusCode == http.StatusAccepted {
+		if response, err = r.pollOperation(ctx, data, hook.Poll, response); err != nil {
+			diags.AddError("Lifecycle Hook Failed", fmt.Sprintf("%s: %s", name, err))
+			return
+		}
+	}
+
+	// The version was checked by the hook and is stale now
+	if versioned {
+		data.ConcurrencyToken = types.StringNull()
+	}
+
+	if err := r.recordHookResponse(ctx, data, name, response); err != nil {
+		diags.AddError("Response Processing Error", err.Error())
+	}
+}
+
+// recordHookResponse stores a hook response in hook_responses, applying the
+// same redaction as the object's own responses
+func (r *RestResource) recordHookResponse(ctx context.Context, data *RestResourceModel, name string, response *client.Response) error {
+	body := types.StringNull()
+	if !data.SensitiveResponse.ValueBool() {
+		redacted := response.Body
+		if !data.RedactResponsePaths.IsNull() && len(redacted) > 0 {
+			var patterns []string
+			if diags := data.RedactResponsePaths.ElementsAs(ctx, &patterns, false); diags.HasError() {
+				return fmt.Errorf("failed to read redact_response_paths: %v", diags.Errors())
+			}
+			var err error
+			if redacted, err = redactJSONBody(redacted, patterns); err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+		}
+		body = types.StringValue(string(redacted))
+	}
+
+	entry, diags := types.ObjectValue(hookResponseAttrTypes, map[string]attr.Value{
+		"status_code": types.Int64Value(int64(response.StatusCode)),
+		"body":        body,
+	})
+	if diags.HasError() {
+		return fmt.Errorf("%s: cannot record the response", name)
+	}
+
+	entries := make(map[string]attr.Value)
+	if !data.HookResponses.IsNull() && !data.HookResponses.IsUnknown() {
+		for key, value := range data.HookResponses.Elements() {
+			entries[key] = value
+		}
+	}
+	entries[name] = entry
+
+	responses, diags := types.MapValue(types.ObjectType{AttrTypes: hookResponseAttrTypes}, entries)
+	if diags.HasError() {
+		return fmt.Errorf("%s: cannot record the response", name)
+	}
+	data.HookResponses = responses
+	return nil
+}
@@ -0,0 +1,188 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-rest/internal/client"
+)
+
+func TestRestResource_RunHook(t *testing.T) {
+	tests := []struct {
+		name         string
+		hookName     string
+		hook         *HookModel
+		locking      *OptimisticLockingModel
+		status       int
+		expectURL    string
+		expectMethod string
+		expectBody   string
+		expectHeader string
+		response     string
+		errMsg       string
+	}{
+		{
+			name:         "post_create with id placeholder",
+			hookName:     "post_create",
+			hook:         &HookModel{Path: types.StringValue("/items/{id}/activate"), Method: types.StringNull(), Body: types.StringNull()},
+			status:       http.StatusOK,
+			expectURL:    "/items/42/activate",
+			expectMethod: "POST",
+			response:     `{"active":true}`,
+		},
+		{
+			name:     "pre_delete with body and expected status",
+			hookName: "pre_delete",
+			hook: &HookModel{
+				Path:           types.StringValue("/items/{id}/disable"),
+				Method:         types.StringValue("PUT"),
+				Body:           types.StringValue(`{"reason":"terraform destroy"}`),
+				ExpectedStatus: statusValues("204"),
+			},
+			status:       http.StatusNoContent,
+			expectURL:    "/items/42/disable",
+			expectMethod: "PUT",
+			expectBody:   `{"reason":"terraform destroy"}`,
+			response:     ``,
+		},
+		{
+			name:         "unexpected status",
+			hookName:     "post_update",
+			hook:         &HookModel{Path: types.StringValue("/items/{id}/publish"), Method: types.StringNull(), Body: types.StringNull(), ExpectedStatus: statusValues("200")},
+			status:       http.StatusNoContent,
+			expectURL:    "/items/42/publish",
+			expectMethod: "POST",
+			errMsg:       "returned status 204",
+		},
+		{
+			name:         "pre_update carries the version check",
+			hookName:     "pre_update",
+			hook:         &HookModel{Path: types.StringValue("/items/{id}/lock"), Method: types.StringNull(), Body: types.StringNull()},
+			locking:      &OptimisticLockingModel{Source: types.StringNull()},
+			status:       http.StatusOK,
+			expectURL:    "/items/42/lock",
+			expectMethod: "POST",
+			expectHeader: `"v7"`,
+			response:     `{}`,
+		},
+		{
+			name:         "pre_update version conflict",
+			hookName:     "pre_update",
+			hook:         &HookModel{Path: types.StringValue("/items/{id}/lock"), Method: types.StringNull(), Body: types.StringNull()},
+			locking:      &OptimisticLockingModel{Source: types.StringNull()},
+			status:       http.StatusPreconditionFailed,
+			expectURL:    "/items/42/lock",
+			expectMethod: "POST",
+			expectHeader: `"v7"`,
+			errMsg:       "changed outside Terraform",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requestURL, method, body, ifMatch string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requestURL = r.URL.Path
+				method = r.Method
+				ifMatch = r.Header.Get("If-Match")
+				requestBody, _ := io.ReadAll(r.Body)
+				body = string(requestBody)
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			restClient, err := client.NewRestClient(client.Config{BaseURL: server.URL})
+			if err != nil {
+				t.Fatalf("Failed to create client: %s", err)
+			}
+			r := &RestResource{client: restClient}
+			data := &RestResourceModel{
+				Endpoint:            types.StringValue("/items"),
+				Id:                  types.StringValue("42"),
+				PreUpdate:           tt.hook,
+				PreDelete:           tt.hook,
+				PostCreate:          tt.hook,
+				PostUpdate:          tt.hook,
+				OptimisticLocking:   tt.locking,
+				ConcurrencyToken:    types.StringValue(`"v7"`),
+				RedactResponsePaths: types.ListNull(types.StringType),
+				HookResponses:       types.MapNull(types.ObjectType{AttrTypes: hookResponseAttrTypes}),
+			}
+			var diags diag.Diagnostics
+
+			r.runHook(context.Background(), data, tt.hookName, &diags)
+
+			if requestURL != tt.expectURL || method != tt.expectMethod {
+				t.Errorf("Expected %s %s, got %s %s", tt.expectMethod, tt.expectURL, method, requestURL)
+			}
+			if body != tt.expectBody {
+				t.Errorf("Expected body %q, got %q", tt.expectBody, body)
+			}
+			if ifMatch != tt.expectHeader {
+				t.Errorf("Expected If-Match %q, got %q", tt.expectHeader, ifMatch)
+			}
+			if tt.errMsg != "" {
+				if !diags.HasError() || !strings.Contains(diags.Errors()[0].Detail(), tt.errMsg) {
+					t.Errorf("Expected error containing %q, got %v", tt.errMsg, diags)
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("Unexpected errors: %v", diags)
+			}
+
+			entry, ok := data.HookResponses.Elements()[tt.hookName].(types.Object)
+			if !ok {
+				t.Fatalf("Expected a %s entry in hook_responses, got %v", tt.hookName, data.HookResponses)
+			}
+			attributes := entry.Attributes()
+			if !attributes["status_code"].Equal(types.Int64Value(int64(tt.status))) || !attributes["body"].Equal(types.StringValue(tt.response)) {
+				t.Errorf("Unexpected hook response %v", entry)
+			}
+			if tt.locking != nil && !data.ConcurrencyToken.IsNull() {
+				t.Errorf("Expected the checked version to be cleared, got %s", data.ConcurrencyToken)
+			}
+		})
+	}
+}
+
+func TestRestResource_RecordHookResponse(t *testing.T) {
+	r := &RestResource{}
+	data := &RestResourceModel{
+		RedactResponsePaths: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("secret")}),
+		HookResponses:       types.MapNull(types.ObjectType{AttrTypes: hookResponseAttrTypes}),
+	}
+
+	if err := r.recordHookResponse(context.Background(), data, "pre_create", &client.Response{StatusCode: 201, Body: []byte(`{"name":"a","secret":"s3cr3t"}`)}); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if err := r.recordHookResponse(context.Background(), data, "post_create", &client.Response{StatusCode: 200, Body: []byte(`{}`)}); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	elements := data.HookResponses.Elements()
+	if len(elements) != 2 {
+		t.Fatalf("Expected both hook responses, got %v", elements)
+	}
+	body := elements["pre_create"].(types.Object).Attributes()["body"].(types.String).ValueString()
+	if strings.Contains(body, "s3cr3t") {
+		t.Errorf("Expected the secret to be redacted, got %s", body)
+	}
+
+	// Sensitive responses are not recorded in plain text
+	data.SensitiveResponse = types.BoolValue(true)
+	if err := r.recordHookResponse(context.Background(), data, "post_create", &client.Response{StatusCode: 200, Body: []byte(`{"token":"x"}`)}); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if !data.HookResponses.Elements()["post_create"].(types.Object).Attributes()["body"].IsNull() {
+		t.Errorf("Expected a null body with sensitive_response")
+	}
+}
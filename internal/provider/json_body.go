@@ -0,0 +1,196 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var (
+	_ basetypes.StringTypable                    = JSONBodyType{}
+	_ basetypes.StringValuableWithSemanticEquals = JSONBody{}
+	_ xattr.ValidateableAttribute                = JSONBody{}
+)
+
+// JSONBodyType is the attribute type for request bodies. Bodies that are JSON
+// compare by content, so formatting and key order do not matter; other bodies
+// such as form data or XML compare as plain strings.
+type JSONBodyType struct {
+	basetypes.StringType
+}
+
+func (t JSONBodyType) String() string {
+	return "JSONBodyType"
+}
+
+func (t JSONBodyType) ValueType(ctx context.Context) attr.Value {
+	return JSONBody{}
+}
+
+func (t JSONBodyType) Equal(o attr.Type) bool {
+	other, ok := o.(JSONBodyType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t JSONBodyType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return JSONBody{StringValue: in}, nil
+}
+
+func (t JSONBodyType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	stringValuable, diags := t.ValueFromString(ctx, stringValue)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unexpected error converting StringValue to StringValuable: %v", diags)
+	}
+
+	return stringValuable, nil
+}
+
+// JSONBody is a request body value. See JSONBodyType.
+type JSONBody struct {
+	basetypes.StringValue
+}
+
+// NewJSONBodyNull creates a null body
+func NewJSONBodyNull() JSONBody {
+	return JSONBody{StringValue: basetypes.NewStringNull()}
+}
+
+// NewJSONBodyValue creates a known body
+func NewJSONBodyValue(value string) JSONBody {
+	return JSONBody{StringValue: basetypes.NewStringValue(value)}
+}
+
+func (v JSONBody) Type(ctx context.Context) attr.Type {
+	return JSONBodyType{}
+}
+
+func (v JSONBody) Equal(o attr.Value) bool {
+	other, ok := o.(JSONBody)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+// StringSemanticEquals reports whether two bodies are the same JSON document.
+// Bodies that are not both JSON are equal only if identical.
+func (v JSONBody) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(JSONBody)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T but got value type %T. Please report this to the provider developers.", v, newValuable),
+		)
+		return false, diags
+	}
+
+	if v.ValueString() == newValue.ValueString() {
+		return true, diags
+	}
+
+	prior, err := decodeJSONDocument([]byte(v.ValueString()))
+	if err != nil {
+		return false, diags
+	}
+	current, err := decodeJSONDocument([]byte(newValue.ValueString()))
+	if err != nil {
+		return false, diags
+	}
+
+	return jsonDocumentsEqual(prior, current), diags
+}
+
+// jsonDocumentsEqual compares two documents decoded by decodeJSONDocument.
+// Numbers compare by value at full precision, so 1.0 equals 1 but large
+// integers that differ in their last digit do not.
+func jsonDocumentsEqual(a, b interface{}) bool {
+	switch aValue := a.(type) {
+	case map[string]interface{}:
+		bValue, ok := b.(map[string]interface{})
+		if !ok || len(aValue) != len(bValue) {
+			return false
+		}
+		for key, element := range aValue {
+			other, exists := bValue[key]
+			if !exists || !jsonDocumentsEqual(element, other) {
+				return false
+			}
+		}
+		return true
+
+	case []interface{}:
+		bValue, ok := b.([]interface{})
+		if !ok || len(aValue) != len(bValue) {
+			return false
+		}
+		for i := range aValue {
+			if !jsonDocumentsEqual(aValue[i], bValue[i]) {
+				return false
+			}
+		}
+		return true
+
+	case json.Number:
+		bValue, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		aNumber, _, aErr := big.ParseFloat(aValue.String(), 10, 512, big.ToNearestEven)
+		bNumber, _, bErr := big.ParseFloat(bValue.String(), 10, 512, big.ToNearestEven)
+		if aErr != nil || bErr != nil {
+			return aValue == bValue
+		}
+		return aNumber.Cmp(bNumber) == 0
+	}
+
+	return a == b
+}
+
+// ValidateAttribute rejects bodies that look like JSON objects or arrays but do
+// not parse, so mistakes in hand-written JSON surface at plan time rather than
+// as an API error during apply
+func (v JSONBody) ValidateAttribute(ctx context.Context, req xattr.ValidateAttributeRequest, resp *xattr.ValidateAttributeResponse) {
+	if v.IsNull() || v.IsUnknown() || !looksLikeJSON(v.ValueString()) {
+		return
+	}
+
+	var document interface{}
+	if err := json.Unmarshal([]byte(v.ValueString()), &document); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid JSON Body",
+			fmt.Sprintf("The body starts like a JSON document but is not valid JSON: %s. Use jsonencode() to build JSON bodies.", err),
+		)
+	}
+}
+
+// looksLikeJSON reports whether a body is meant to be a JSON object or array
+func looksLikeJSON(body string) bool {
+	trimmed := strings.TrimSpace(body)
+	return strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[")
+}
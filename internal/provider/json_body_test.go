@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+func TestJSONBody_StringSemanticEquals(t *testing.T) {
+	tests := []struct {
+		name     string
+		prior    string
+		current  string
+		expected bool
+	}{
+		{name: "identical", prior: `{"a":1}`, current: `{"a":1}`, expected: true},
+		{name: "whitespace", prior: `{"a":1,"b":[1,2]}`, current: "{\n  \"a\": 1,\n  \"b\": [1, 2]\n}", expected: true},
+		{name: "key order", prior: `{"a":1,"b":2}`, current: `{"b":2,"a":1}`, expected: true},
+		{name: "number formatting", prior: `{"a":1}`, current: `{"a":1.0}`, expected: true},
+		{name: "exponent formatting", prior: `{"a":100}`, current: `{"a":1e2}`, expected: true},
+		{name: "different value", prior: `{"a":1}`, current: `{"a":2}`, expected: false},
+		{name: "large integers differ", prior: `{"id":12345678901234567}`, current: `{"id":12345678901234568}`, expected: false},
+		{name: "number and string differ", prior: `{"a":1}`, current: `{"a":"1"}`, expected: false},
+		{name: "extra key", prior: `{"a":1}`, current: `{"a":1,"b":null}`, expected: false},
+		{name: "array order matters", prior: `[1,2]`, current: `[2,1]`, expected: false},
+		{name: "non-JSON identical", prior: `a=1&b=2`, current: `a=1&b=2`, expected: true},
+		{name: "non-JSON different", prior: `a=1&b=2`, current: `b=2&a=1`, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			equal, diags := NewJSONBodyValue(tt.prior).StringSemanticEquals(context.Background(), NewJSONBodyValue(tt.current))
+			if diags.HasError() {
+				t.Fatalf("Unexpected diagnostics: %v", diags)
+			}
+			if equal != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, equal)
+			}
+		})
+	}
+}
+
+func TestJSONBody_ValidateAttribute(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     JSONBody
+		expectErr bool
+	}{
+		{name: "valid object", value: NewJSONBodyValue(`{"a": 1}`)},
+		{name: "valid array", value: NewJSONBodyValue(` [1, 2]`)},
+		{name: "form data", value: NewJSONBodyValue(`a=1&b=2`)},
+		{name: "xml", value: NewJSONBodyValue(`<item id="1"/>`)},
+		{name: "null", value: NewJSONBodyNull()},
+		{name: "invalid object", value: NewJSONBodyValue(`{"a": 1,}`), expectErr: true},
+		{name: "truncated array", value: NewJSONBodyValue(`[1, 2`), expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &xattr.ValidateAttributeResponse{}
+			tt.value.ValidateAttribute(context.Background(), xattr.ValidateAttributeRequest{Path: path.Root("body")}, resp)
+
+			if resp.Diagnostics.HasError() != tt.expectErr {
+				t.Errorf("Expected error=%v, got %v", tt.expectErr, resp.Diagnostics)
+			}
+		})
+	}
+}
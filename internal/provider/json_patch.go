@@ -0,0 +1,200 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const (
+	updateStrategyPutFull    = "put_full"
+	updateStrategyMergePatch = "merge_patch"
+	updateStrategyJSONPatch  = "json_patch"
+
+	mergePatchContentType = "application/merge-patch+json"
+	jsonPatchContentType  = "application/json-patch+json"
+)
+
+// jsonPatchOperation is a single RFC 6902 operation
+type jsonPatchOperation struct {
+	Op    string
+	Path  string
+	Value interface{}
+}
+
+// MarshalJSON always writes the value of add and replace operations, since a
+// JSON null is a legitimate value for them
+func (o jsonPatchOperation) MarshalJSON() ([]byte, error) {
+	if o.Op == "remove" {
+		return json.Marshal(struct {
+			Op   string `json:"op"`
+			Path string `json:"path"`
+		}{o.Op, o.Path})
+	}
+	return json.Marshal(struct {
+		Op    string      `json:"op"`
+		Path  string      `json:"path"`
+		Value interface{} `json:"value"`
+	}{o.Op, o.Path, o.Value})
+}
+
+// updateStrategy returns the configured update strategy
+func updateStrategy(data *RestResourceModel) string {
+	if data.UpdateStrategy.IsNull() || data.UpdateStrategy.IsUnknown() {
+		return updateStrategyPutFull
+	}
+	return data.UpdateStrategy.ValueString()
+}
+
+// updateDocument returns the body an update sends under put_full: update_body,
+// then body_wo, then body
+func updateDocument(data *RestResourceModel) JSONBody {
+	switch {
+	case !data.UpdateBody.IsNull():
+		return data.UpdateBody
+	case !data.BodyWO.IsNull():
+		return data.BodyWO
+	default:
+		return data.Body
+	}
+}
+
+// buildPatchBody computes the patch document that turns the prior body into the
+// planned body, returning it with its content type
+func buildPatchBody(strategy string, prior, planned JSONBody) (string, string, error) {
+	if planned.IsNull() {
+		return "", "", fmt.Errorf("update_strategy %q needs a body or update_body to compute a patch", strategy)
+	}
+	if prior.IsNull() {
+		return "", "", fmt.Errorf("update_strategy %q cannot compute a patch because no previous body is stored in state; body_wo is never stored, so use put_full with it", strategy)
+	}
+
+	original, err := decodeJSONDocument([]byte(prior.ValueString()))
+	if err != nil {
+		return "", "", fmt.Errorf("update_strategy %q requires a JSON body; the previous body is not JSON: %w", strategy, err)
+	}
+	target, err := decodeJSONDocument([]byte(planned.ValueString()))
+	if err != nil {
+		return "", "", fmt.Errorf("update_strategy %q requires a JSON body; the planned body is not JSON: %w", strategy, err)
+	}
+
+	var patch interface{}
+	contentType := mergePatchContentType
+	if strategy == updateStrategyJSONPatch {
+		patch = createJSONPatch(original, target)
+		contentType = jsonPatchContentType
+	} else {
+		patch = createMergePatch(original, target)
+	}
+
+	encoded, err := json.Marshal(patch)
+	if err != nil {
+		return "", "", fmt.Errorf("encoding %s patch: %w", strategy, err)
+	}
+	return string(encoded), contentType, nil
+}
+
+// createMergePatch returns the RFC 7396 merge patch from original to target.
+// Removed fields become null, and anything that is not an object on both sides
+// is replaced whole. Merge patches cannot set a field to null, so a null in the
+// target removes the field instead.
+func createMergePatch(original, target interface{}) interface{} {
+	originalObject, ok := original.(map[string]interface{})
+	targetObject, targetOK := target.(map[string]interface{})
+	if !ok || !targetOK {
+		return target
+	}
+
+	patch := make(map[string]interface{})
+	for key := range originalObject {
+		if _, ok := targetObject[key]; !ok {
+			patch[key] = nil
+		}
+	}
+	for key, targetValue := range targetObject {
+		originalValue, ok := originalObject[key]
+		if !ok {
+			patch[key] = targetValue
+			continue
+		}
+		if reflect.DeepEqual(originalValue, targetValue) {
+			continue
+		}
+		_, originalIsObject := originalValue.(map[string]interface{})
+		_, targetIsObject := targetValue.(map[string]interface{})
+		if originalIsObject && targetIsObject {
+			patch[key] = createMergePatch(originalValue, targetValue)
+		} else {
+			patch[key] = targetValue
+		}
+	}
+	return patch
+}
+
+// createJSONPatch returns the RFC 6902 operations that turn original into
+// target. Objects are compared field by field in key order; arrays that differ
+// are replaced whole, which keeps the operations valid regardless of how the
+// elements moved.
+func createJSONPatch(original, target interface{}) []jsonPatchOperation {
+	operations := []jsonPatchOperation{}
+	diffJSONPatch(original, target, "", &operations)
+	return operations
+}
+
+func diffJSONPatch(original, target interface{}, pointer string, operations *[]jsonPatchOperation) {
+	if reflect.DeepEqual(original, target) {
+		return
+	}
+
+	originalObject, ok := original.(map[string]interface{})
+	targetObject, targetOK := target.(map[string]interface{})
+	if !ok || !targetOK {
+		*operations = append(*operations, jsonPatchOperation{Op: "replace", Path: pointer, Value: target})
+		return
+	}
+
+	keys := make([]string, 0, len(originalObject)+len(targetObject))
+	for key := range originalObject {
+		keys = append(keys, key)
+	}
+	for key := range targetObject {
+		if _, ok := originalObject[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		child := pointer + "/" + escapeJSONPointer(key)
+		originalValue, inOriginal := originalObject[key]
+		targetValue, inTarget := targetObject[key]
+		switch {
+		case !inTarget:
+			*operations = append(*operations, jsonPatchOperation{Op: "remove", Path: child})
+		case !inOriginal:
+			*operations = append(*operations, jsonPatchOperation{Op: "add", Path: child, Value: targetValue})
+		default:
+			diffJSONPatch(originalValue, targetValue, child, operations)
+		}
+	}
+}
+
+// escapeJSONPointer escapes a key for use as an RFC 6901 reference token
+func escapeJSONPointer(key string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(key)
+}
+
+// hasHeader reports whether headers contain name, compared case-insensitively
+func hasHeader(headers map[string]string, name string) bool {
+	for key := range headers {
+		if http.CanonicalHeaderKey(key) == http.CanonicalHeaderKey(name) {
+			return true
+		}
+	}
+	return false
+}
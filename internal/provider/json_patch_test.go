@@ -0,0 +1,156 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestBuildPatchBody(t *testing.T) {
+	tests := []struct {
+		name        string
+		strategy    string
+		prior       JSONBody
+		planned     JSONBody
+		expected    string
+		contentType string
+		errMsg      string
+	}{
+		{
+			name:        "merge patch of changed, added and removed fields",
+			strategy:    updateStrategyMergePatch,
+			prior:       NewJSONBodyValue(`{"name":"a","size":1,"color":"red"}`),
+			planned:     NewJSONBodyValue(`{"name":"b","size":1,"shape":"round"}`),
+			expected:    `{"color":null,"name":"b","shape":"round"}`,
+			contentType: mergePatchContentType,
+		},
+		{
+			name:        "merge patch recurses into objects and replaces arrays",
+			strategy:    updateStrategyMergePatch,
+			prior:       NewJSONBodyValue(`{"labels":{"team":"a","env":"dev"},"tags":["x","y"]}`),
+			planned:     NewJSONBodyValue(`{"labels":{"team":"b","env":"dev"},"tags":["x"]}`),
+			expected:    `{"labels":{"team":"b"},"tags":["x"]}`,
+			contentType: mergePatchContentType,
+		},
+		{
+			name:        "merge patch of identical bodies is empty",
+			strategy:    updateStrategyMergePatch,
+			prior:       NewJSONBodyValue(`{"name":"a","size":1}`),
+			planned:     NewJSONBodyValue(`{"size":1, "name":"a"}`),
+			expected:    `{}`,
+			contentType: mergePatchContentType,
+		},
+		{
+			name:        "merge patch keeps large integers exact",
+			strategy:    updateStrategyMergePatch,
+			prior:       NewJSONBodyValue(`{"id":9007199254740993}`),
+			planned:     NewJSONBodyValue(`{"id":9007199254740995}`),
+			expected:    `{"id":9007199254740995}`,
+			contentType: mergePatchContentType,
+		},
+		{
+			name:        "json patch operations in key order",
+			strategy:    updateStrategyJSONPatch,
+			prior:       NewJSONBodyValue(`{"name":"a","color":"red","labels":{"team":"a"}}`),
+			planned:     NewJSONBodyValue(`{"name":"b","labels":{"team":"a","env":"dev"},"size":null}`),
+			expected:    `[{"op":"remove","path":"/color"},{"op":"add","path":"/labels/env","value":"dev"},{"op":"replace","path":"/name","value":"b"},{"op":"add","path":"/size","value":null}]`,
+			contentType: jsonPatchContentType,
+		},
+		{
+			name:        "json patch replaces changed arrays whole",
+			strategy:    updateStrategyJSONPatch,
+			prior:       NewJSONBodyValue(`{"tags":["x","y"]}`),
+			planned:     NewJSONBodyValue(`{"tags":["y"]}`),
+			expected:    `[{"op":"replace","path":"/tags","value":["y"]}]`,
+			contentType: jsonPatchContentType,
+		},
+		{
+			name:        "json patch escapes pointer tokens",
+			strategy:    updateStrategyJSONPatch,
+			prior:       NewJSONBodyValue(`{}`),
+			planned:     NewJSONBodyValue(`{"a/b~c":1}`),
+			expected:    `[{"op":"add","path":"/a~1b~0c","value":1}]`,
+			contentType: jsonPatchContentType,
+		},
+		{
+			name:        "json patch replaces a non-object root",
+			strategy:    updateStrategyJSONPatch,
+			prior:       NewJSONBodyValue(`[1]`),
+			planned:     NewJSONBodyValue(`[2]`),
+			expected:    `[{"op":"replace","path":"","value":[2]}]`,
+			contentType: jsonPatchContentType,
+		},
+		{
+			name:        "json patch of identical bodies is empty",
+			strategy:    updateStrategyJSONPatch,
+			prior:       NewJSONBodyValue(`{"name":"a"}`),
+			planned:     NewJSONBodyValue(`{"name":"a"}`),
+			expected:    `[]`,
+			contentType: jsonPatchContentType,
+		},
+		{
+			name:     "non-JSON body",
+			strategy: updateStrategyMergePatch,
+			prior:    NewJSONBodyValue(`{"name":"a"}`),
+			planned:  NewJSONBodyValue(`name=b`),
+			errMsg:   "planned body is not JSON",
+		},
+		{
+			name:     "no prior body",
+			strategy: updateStrategyJSONPatch,
+			prior:    NewJSONBodyNull(),
+			planned:  NewJSONBodyValue(`{"name":"b"}`),
+			errMsg:   "no previous body is stored in state",
+		},
+		{
+			name:     "no planned body",
+			strategy: updateStrategyJSONPatch,
+			prior:    NewJSONBodyValue(`{"name":"a"}`),
+			planned:  NewJSONBodyNull(),
+			errMsg:   "needs a body or update_body",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, contentType, err := buildPatchBody(tt.strategy, tt.prior, tt.planned)
+
+			if tt.errMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+					t.Errorf("Expected error containing %q, got %v", tt.errMsg, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if body != tt.expected {
+				t.Errorf("Expected patch %s, got %s", tt.expected, body)
+			}
+			if contentType != tt.contentType {
+				t.Errorf("Expected content type %s, got %s", tt.contentType, contentType)
+			}
+		})
+	}
+}
+
+func TestRestResource_ResolveUpdateMethodForStrategy(t *testing.T) {
+	r := &RestResource{}
+
+	data := &RestResourceModel{UpdateStrategy: types.StringValue(updateStrategyMergePatch)}
+	if method := r.resolveMethodForOperation(data, "update"); method != "PATCH" {
+		t.Errorf("Expected PATCH for merge_patch, got %s", method)
+	}
+
+	data.UpdateMethod = types.StringValue("POST")
+	if method := r.resolveMethodForOperation(data, "update"); method != "POST" {
+		t.Errorf("Expected an explicit update_method to win, got %s", method)
+	}
+
+	data = &RestResourceModel{UpdateStrategy: types.StringNull()}
+	if method := r.resolveMethodForOperation(data, "update"); method != "PUT" {
+		t.Errorf("Expected PUT for put_full, got %s", method)
+	}
+}
@@ -0,0 +1,332 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// pathSegment is one step of a parsed JSON path
+type pathSegment struct {
+	key      string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+// parseJSONPath parses a JSON pointer ("/data/uuid") or a dotted path
+// ("data.uuid", "$.items[0].name", "$['odd.key']"). An empty path or "$"
+// refers to the document root.
+func parseJSONPath(expr string) ([]pathSegment, error) {
+	expr = strings.TrimSpace(expr)
+
+	// JSON pointer (RFC 6901)
+	if strings.HasPrefix(expr, "/") {
+		tokens := strings.Split(expr[1:], "/")
+		segments := make([]pathSegment, 0, len(tokens))
+		for _, token := range tokens {
+			token = strings.ReplaceAll(token, "~1", "/")
+			token = strings.ReplaceAll(token, "~0", "~")
+			segments = append(segments, pathSegment{key: token})
+		}
+		return segments, nil
+	}
+
+	expr = strings.TrimPrefix(expr, "$")
+	var segments []pathSegment
+
+	for len(expr) > 0 {
+		switch expr[0] {
+		case '.':
+			expr = expr[1:]
+		case '[':
+			rest := expr[1:]
+
+			// Quoted keys may contain '.' or ']'
+			if len(rest) > 0 && (rest[0] == '\'' || rest[0] == '"') {
+				quote := rest[0]
+				closing := strings.IndexByte(rest[1:], quote)
+				if closing < 0 {
+					return nil, fmt.Errorf("unterminated quoted key")
+				}
+				key := rest[1 : 1+closing]
+				rest = rest[2+closing:]
+				if !strings.HasPrefix(rest, "]") {
+					return nil, fmt.Errorf("expected ']' after quoted key %q", key)
+				}
+				segments = append(segments, pathSegment{key: key})
+				expr = rest[1:]
+				continue
+			}
+
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated '[' in path")
+			}
+			inner := strings.TrimSpace(rest[:end])
+			if inner == "*" {
+				segments = append(segments, pathSegment{wildcard: true})
+			} else {
+				index, err := strconv.Atoi(inner)
+				if err != nil {
+					return nil, fmt.Errorf("invalid array index %q", inner)
+				}
+				segments = append(segments, pathSegment{index: index, isIndex: true})
+			}
+			expr = rest[end+1:]
+		default:
+			end := strings.IndexAny(expr, ".[")
+			if end < 0 {
+				end = len(expr)
+			}
+			key := expr[:end]
+			if key == "*" {
+				segments = append(segments, pathSegment{wildcard: true})
+			} else {
+				segments = append(segments, pathSegment{key: key})
+			}
+			expr = expr[end:]
+		}
+	}
+
+	return segments, nil
+}
+
+// lookupJSONPath resolves a path against a decoded JSON document. Key segments
+// that are numeric also index into arrays, so "items.0" and "/items/0" work.
+func lookupJSONPath(document interface{}, expr string) (interface{}, bool, error) {
+	segments, err := parseJSONPath(expr)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid path %q: %w", expr, err)
+	}
+
+	current := document
+	for _, segment := range segments {
+		if segment.wildcard {
+			return nil, false, fmt.Errorf("invalid path %q: wildcards are not supported here", expr)
+		}
+
+		switch node := current.(type) {
+		case map[string]interface{}:
+			if segment.isIndex {
+				return nil, false, nil
+			}
+			value, ok := node[segment.key]
+			if !ok {
+				return nil, false, nil
+			}
+			current = value
+		case []interface{}:
+			index := segment.index
+			if !segment.isIndex {
+				index, err = strconv.Atoi(segment.key)
+				if err != nil {
+					return nil, false, nil
+				}
+			}
+			if index < 0 {
+				index += len(node)
+			}
+			if index < 0 || index >= len(node) {
+				return nil, false, nil
+			}
+			current = node[index]
+		default:
+			return nil, false, nil
+		}
+	}
+
+	return current, true, nil
+}
+
+// decodeJSONDocument decodes a JSON body, keeping numbers as json.Number so
+// large integer IDs survive without float rounding
+func decodeJSONDocument(body []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var document interface{}
+	if err := decoder.Decode(&document); err != nil {
+		return nil, err
+	}
+	return document, nil
+}
+
+// jsonScalarString renders a JSON scalar as a string; objects, arrays and null
+// cannot be represented and return false
+func jsonScalarString(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case json.Number:
+		return v.String(), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(v), true
+	default:
+		return "", false
+	}
+}
+
+// redactJSONBody removes the fields matched by path patterns from a JSON body.
+// Bodies that are not JSON are returned unchanged since there is nothing to
+// address by path.
+func redactJSONBody(body []byte, patterns []string) ([]byte, error) {
+	document, err := decodeJSONDocument(body)
+	if err != nil {
+		return body, nil
+	}
+
+	for _, pattern := range patterns {
+		segments, err := parsePathPattern(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redact_response_paths entry %q: %w", pattern, err)
+		}
+		removeJSONPath(document, segments)
+	}
+
+	return json.Marshal(document)
+}
+
+// removeJSONPath deletes the object fields addressed by segments. Wildcards match
+// every key or element along the way. Array elements are never removed since
+// that would shift the indexes of the remaining elements.
+func removeJSONPath(node interface{}, segments []pathSegment) {
+	if len(segments) == 0 {
+		return
+	}
+	segment := segments[0]
+	last := len(segments) == 1
+
+	switch value := node.(type) {
+	case map[string]interface{}:
+		if segment.isIndex {
+			return
+		}
+		if segment.wildcard {
+			for key, child := range value {
+				if last {
+					delete(value, key)
+				} else {
+					removeJSONPath(child, segments[1:])
+				}
+			}
+			return
+		}
+		if last {
+			delete(value, segment.key)
+		} else if child, ok := value[segment.key]; ok {
+			removeJSONPath(child, segments[1:])
+		}
+
+	case []interface{}:
+		if last {
+			return
+		}
+		if segment.wildcard {
+			for _, child := range value {
+				removeJSONPath(child, segments[1:])
+			}
+			return
+		}
+		index := segment.index
+		if !segment.isIndex {
+			parsed, err := strconv.Atoi(segment.key)
+			if err != nil {
+				return
+			}
+			index = parsed
+		}
+		if index < 0 {
+			index += len(value)
+		}
+		if index >= 0 && index < len(value) {
+			removeJSONPath(value[index], segments[1:])
+		}
+	}
+}
+
+// setJSONPath sets the value addressed by path in a decoded JSON object,
+// creating intermediate objects as needed. Array elements must already exist.
+func setJSONPath(document interface{}, path string, value interface{}) error {
+	segments, err := parseJSONPath(path)
+	if err != nil {
+		return err
+	}
+	if len(segments) == 0 {
+		return fmt.Errorf("cannot replace the document root")
+	}
+
+	current := document
+	for i, segment := range segments {
+		last := i == len(segments)-1
+		if segment.wildcard {
+			return fmt.Errorf("wildcards cannot be used to set a value")
+		}
+
+		switch node := current.(type) {
+		case map[string]interface{}:
+			if segment.isIndex {
+				return fmt.Errorf("cannot index object with [%d]", segment.index)
+			}
+			if last {
+				node[segment.key] = value
+				return nil
+			}
+			child, ok := node[segment.key]
+			if !ok || child == nil {
+				child = make(map[string]interface{})
+				node[segment.key] = child
+			}
+			current = child
+
+		case []interface{}:
+			index := segment.index
+			if !segment.isIndex {
+				parsed, err := strconv.Atoi(segment.key)
+				if err != nil {
+					return fmt.Errorf("cannot use key %q on an array", segment.key)
+				}
+				index = parsed
+			}
+			if index < 0 || index >= len(node) {
+				return fmt.Errorf("array index %d out of range", index)
+			}
+			if last {
+				node[index] = value
+				return nil
+			}
+			current = node[index]
+
+		default:
+			return fmt.Errorf("cannot set %q inside a %T", path, current)
+		}
+	}
+	return nil
+}
+
+// jsonPointer renders a path as an RFC 6901 JSON pointer
+func jsonPointer(path string) (string, error) {
+	segments, err := parseJSONPath(path)
+	if err != nil {
+		return "", err
+	}
+
+	var pointer strings.Builder
+	for _, segment := range segments {
+		switch {
+		case segment.wildcard:
+			return "", fmt.Errorf("wildcards cannot be used in a JSON pointer")
+		case segment.isIndex:
+			pointer.WriteString("/" + strconv.Itoa(segment.index))
+		default:
+			pointer.WriteString("/" + escapeJSONPointer(segment.key))
+		}
+	}
+	return pointer.String(), nil
+}
@@ -0,0 +1,170 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLookupJSONPath(t *testing.T) {
+	document, err := decodeJSONDocument([]byte(`{
+		"id": 42,
+		"data": {"uuid": "abc-123", "odd.key": "dotted", "a/b": "slashed", "m~n": "tilde"},
+		"items": [{"name": "first"}, {"name": "second"}],
+		"big": 9007199254740993,
+		"ratio": 1.5,
+		"enabled": true,
+		"empty": null
+	}`))
+	if err != nil {
+		t.Fatalf("Failed to decode document: %s", err)
+	}
+
+	tests := []struct {
+		name      string
+		path      string
+		expected  string
+		found     bool
+		expectErr bool
+	}{
+		{name: "top-level number", path: "id", expected: "42", found: true},
+		{name: "dollar prefix", path: "$.id", expected: "42", found: true},
+		{name: "nested dotted path", path: "data.uuid", expected: "abc-123", found: true},
+		{name: "json pointer", path: "/data/uuid", expected: "abc-123", found: true},
+		{name: "json pointer escapes", path: "/data/a~1b", expected: "slashed", found: true},
+		{name: "json pointer tilde escape", path: "/data/m~0n", expected: "tilde", found: true},
+		{name: "json pointer array index", path: "/items/1/name", expected: "second", found: true},
+		{name: "bracket index", path: "$.items[0].name", expected: "first", found: true},
+		{name: "negative index", path: "items[-1].name", expected: "second", found: true},
+		{name: "numeric dotted segment", path: "items.1.name", expected: "second", found: true},
+		{name: "quoted key with dot", path: "$.data['odd.key']", expected: "dotted", found: true},
+		{name: "double quoted key", path: `data["uuid"]`, expected: "abc-123", found: true},
+		{name: "large integer keeps precision", path: "big", expected: "9007199254740993", found: true},
+		{name: "decimal number", path: "ratio", expected: "1.5", found: true},
+		{name: "boolean", path: "enabled", expected: "true", found: true},
+		{name: "missing key", path: "data.missing", found: false},
+		{name: "index out of range", path: "items[5]", found: false},
+		{name: "index into object", path: "data[0]", found: false},
+		{name: "wildcard rejected", path: "items[*].name", expectErr: true},
+		{name: "unterminated bracket", path: "items[0", expectErr: true},
+		{name: "invalid index", path: "items[x]", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, found, err := lookupJSONPath(document, tt.path)
+
+			if tt.expectErr {
+				if err == nil {
+					t.Errorf("Expected error for path %q", tt.path)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if found != tt.found {
+				t.Fatalf("Expected found=%v, got %v", tt.found, found)
+			}
+			if !found {
+				return
+			}
+
+			result, ok := jsonScalarString(value)
+			if !ok {
+				t.Fatalf("Expected scalar value, got %v", value)
+			}
+			if result != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestLookupJSONPath_Root(t *testing.T) {
+	for _, path := range []string{"", "$"} {
+		value, found, err := lookupJSONPath("root", path)
+		if err != nil || !found || value != "root" {
+			t.Errorf("Expected path %q to resolve to the root, got %v, %v, %v", path, value, found, err)
+		}
+	}
+}
+
+func TestRedactJSONBody(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		patterns []string
+		expected string
+		errMsg   string
+	}{
+		{
+			name:     "top-level field",
+			body:     `{"id":"1","secret":"s"}`,
+			patterns: []string{"secret"},
+			expected: `{"id":"1"}`,
+		},
+		{
+			name:     "nested field by dotted path and pointer",
+			body:     `{"credentials":{"user":"u","password":"p","token":"t"}}`,
+			patterns: []string{"credentials.password", "/credentials/token"},
+			expected: `{"credentials":{"user":"u"}}`,
+		},
+		{
+			name:     "array wildcard",
+			body:     `{"keys":[{"id":"a","private_key":"x"},{"id":"b","private_key":"y"}]}`,
+			patterns: []string{"keys[*].private_key"},
+			expected: `{"keys":[{"id":"a"},{"id":"b"}]}`,
+		},
+		{
+			name:     "object wildcard",
+			body:     `{"users":{"a":{"password":"x"},"b":{"password":"y","name":"b"}}}`,
+			patterns: []string{"users.*.password"},
+			expected: `{"users":{"a":{},"b":{"name":"b"}}}`,
+		},
+		{
+			name:     "missing path leaves body unchanged",
+			body:     `{"id":"1"}`,
+			patterns: []string{"credentials.password"},
+			expected: `{"id":"1"}`,
+		},
+		{
+			name:     "array elements are not removed",
+			body:     `{"tags":["a","b"]}`,
+			patterns: []string{"tags[0]"},
+			expected: `{"tags":["a","b"]}`,
+		},
+		{
+			name:     "non-JSON body is returned as is",
+			body:     `secret=s`,
+			patterns: []string{"secret"},
+			expected: `secret=s`,
+		},
+		{
+			name:     "invalid pattern",
+			body:     `{"id":"1"}`,
+			patterns: []string{"items[x"},
+			errMsg:   "invalid redact_response_paths entry",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := redactJSONBody([]byte(tt.body), tt.patterns)
+
+			if tt.errMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+					t.Errorf("Expected error containing %q, got %v", tt.errMsg, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if string(result) != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, string(result))
+			}
+		})
+	}
+}
@@ -0,0 +1,460 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	dschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"terraform-provider-rest/internal/client"
+)
+
+const (
+	paginationLinkHeader = "link_header"
+	paginationNextURL    = "next_url"
+	paginationCursor     = "cursor"
+	paginationOffset     = "offset"
+	paginationPage       = "page"
+
+	defaultMaxPages = 100
+
+	// maxRateLimitRetries bounds how often a page answered with 429 and a
+	// Retry-After header is requested again
+	maxRateLimitRetries = 5
+)
+
+// PaginationModel describes how to follow the pages of a collection response.
+type PaginationModel struct {
+	Type        types.String `tfsdk:"type"`
+	NextPath    types.String `tfsdk:"next_path"`
+	CursorPath  types.String `tfsdk:"cursor_path"`
+	CursorParam types.String `tfsdk:"cursor_param"`
+	OffsetParam types.String `tfsdk:"offset_param"`
+	PageParam   types.String `tfsdk:"page_param"`
+	FirstPage   types.Int64  `tfsdk:"first_page"`
+	LimitParam  types.String `tfsdk:"limit_param"`
+	PageSize    types.Int64  `tfsdk:"page_size"`
+	MaxPages    types.Int64  `tfsdk:"max_pages"`
+	DelayMs     types.Int64  `tfsdk:"delay_ms"`
+}
+
+// paginationDescription is the description of pagination blocks
+const paginationDescription = "How to follow the pages of the collection. Without it, only the first page is read."
+
+// paginationAttributeDescriptions describes each pagination setting
+var paginationAttributeDescriptions = map[string]string{
+	"type":         "Where the next page is found: `link_header` (default) follows the `Link` header entry with `rel=\"next\"`; `next_url` follows the URL at `next_path` in the body; `cursor` sends the token at `cursor_path` as the `cursor_param` query parameter; `offset` and `page` count through the collection with query parameters.",
+	"next_path":    "JSON path or pointer to the next page URL for `type = \"next_url\"`, e.g. `links.next`. A missing, null or empty value ends the collection.",
+	"cursor_path":  "JSON path or pointer to the next page token for `type = \"cursor\"`, e.g. `meta.next_cursor`. A missing, null or empty value ends the collection.",
+	"cursor_param": "Query parameter that carries the token for `type = \"cursor\"`, e.g. `cursor` or `page_token`.",
+	"offset_param": "Query parameter that carries the offset for `type = \"offset\"`. Default: `offset`.",
+	"page_param":   "Query parameter that carries the page number for `type = \"page\"`. Default: `page`.",
+	"first_page":   "Number of the first page for `type = \"page\"`. Default: 1.",
+	"limit_param":  "Query parameter that carries `page_size`. Default: `limit`.",
+	"page_size":    "Number of elements to request per page. For `offset` and `page`, a shorter page is the last one; without it, the collection ends at the first empty page.",
+	"max_pages":    "The most pages to request. Reading fails when more pages remain. Default: 100.",
+	"delay_ms":     "Milliseconds to wait between page requests. Rate limits are respected as well: a `Retry-After` header, or an exhausted `X-RateLimit-Remaining` with `X-RateLimit-Reset`, delays the next page accordingly.",
+}
+
+// paginationTypes lists the valid pagination types
+var paginationTypes = []string{paginationLinkHeader, paginationNextURL, paginationCursor, paginationOffset, paginationPage}
+
+// resourcePaginationAttribute builds the pagination attribute for resource schemas
+func resourcePaginationAttribute() rschema.SingleNestedAttribute {
+	attributes := make(map[string]rschema.Attribute, len(paginationAttributeDescriptions))
+	for name, description := range paginationAttributeDescriptions {
+		switch name {
+		case "type":
+			attributes[name] = rschema.StringAttribute{MarkdownDescription: description, Optional: true, Validators: []validator.String{stringvalidator.OneOf(paginationTypes...)}}
+		case "first_page", "delay_ms":
+			attributes[name] = rschema.Int64Attribute{MarkdownDescription: description, Optional: true, Validators: []validator.Int64{int64validator.AtLeast(0)}}
+		case "page_size", "max_pages":
+			attributes[name] = rschema.Int64Attribute{MarkdownDescription: description, Optional: true, Validators: []validator.Int64{int64validator.AtLeast(1)}}
+		default:
+			attributes[name] = rschema.StringAttribute{MarkdownDescription: description, Optional: true}
+		}
+	}
+
+	return rschema.SingleNestedAttribute{
+		MarkdownDescription: paginationDescription,
+		Optional:            true,
+		Attributes:          attributes,
+	}
+}
+
+// dataSourcePaginationAttribute builds the pagination attribute for data
+// source schemas from the resource one, so the settings stay the same
+func dataSourcePaginationAttribute() dschema.SingleNestedAttribute {
+	resourceAttribute := resourcePaginationAttribute()
+
+	attributes := make(map[string]dschema.Attribute, len(resourceAttribute.Attributes))
+	for name, attribute := range resourceAttribute.Attributes {
+		switch a := attribute.(type) {
+		case rschema.StringAttribute:
+			attributes[name] = dschema.StringAttribute{MarkdownDescription: a.MarkdownDescription, Optional: true, Validators: a.Validators}
+		case rschema.Int64Attribute:
+			attributes[name] = dschema.Int64Attribute{MarkdownDescription: a.MarkdownDescription, Optional: true, Validators: a.Validators}
+		}
+	}
+
+	return dschema.SingleNestedAttribute{
+		MarkdownDescription: resourceAttribute.MarkdownDescription,
+		Optional:            true,
+		Attributes:          attributes,
+	}
+}
+
+// pageLimitError reports that a collection has more pages than max_pages allows
+type pageLimitError struct {
+	pages int
+}
+
+func (e *pageLimitError) Error() string {
+	return fmt.Sprintf("the collection has more than %d pages; raise pagination max_pages", e.pages)
+}
+
+// pager tracks the position in a collection while its pages are requested
+type pager struct {
+	client     *client.RestClient
+	pagination *PaginationModel
+	itemsPath  string
+	first      client.RequestOptions
+	offset     int64
+	number     int64
+}
+
+// fetchPages requests the first page of a collection with options and follows
+// the pages described by pagination, calling visit with each page until it
+// returns true or the collection ends. Without pagination only the first page
+// is requested. Every page is checked against policy, retrying as it asks, and
+// passed to visit with its decision; a page that is not a success ends the
+// collection. itemsPath locates the elements of a page for the offset and page
+// types, which stop at a short or empty page.
+func fetchPages(ctx context.Context, restClient *client.RestClient, options client.RequestOptions, policy *statusPolicy, pagination *PaginationModel, itemsPath string, visit func(page *client.Response, decision statusDecision) (bool, error)) error {
+	if pagination == nil {
+		page, decision, err := doWithStatusPolicy(ctx, restClient, options, policy)
+		if err != nil {
+			return err
+		}
+		_, err = visit(page, decision)
+		return err
+	}
+
+	if err := validatePagination(pagination); err != nil {
+		return err
+	}
+	maxPages := int64(defaultMaxPages)
+	if !pagination.MaxPages.IsNull() && !pagination.MaxPages.IsUnknown() {
+		maxPages = pagination.MaxPages.ValueInt64()
+	}
+
+	p := &pager{client: restClient, pagination: pagination, itemsPath: itemsPath, first: options}
+	options = p.start()
+
+	rateLimited := 0
+	for pages := int64(1); ; {
+		page, decision, err := doWithStatusPolicy(ctx, restClient, options, policy)
+		if err != nil {
+			return err
+		}
+
+		// A page refused for its rate limit is requested again once the
+		// server allows it
+		if page.StatusCode == http.StatusTooManyRequests && decision.outcome != statusSucceeded && rateLimited < maxRateLimitRetries {
+			if wait, ok := parseRetryAfter(http.Header(page.Headers).Get("Retry-After")); ok {
+				rateLimited++
+				if err := p.sleep(ctx, wait, options.Endpoint); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		rateLimited = 0
+
+		done, err := visit(page, decision)
+		if err != nil || done || decision.outcome != statusSucceeded {
+			return err
+		}
+
+		next, more, err := p.next(options, page)
+		if err != nil || !more {
+			return err
+		}
+		if pages >= maxPages {
+			return &pageLimitError{pages: int(maxPages)}
+		}
+		pages++
+
+		if err := p.sleep(ctx, p.delay(page), next.Endpoint); err != nil {
+			return err
+		}
+		tflog.Debug(ctx, "following next page", map[string]interface{}{
+			"endpoint": next.Endpoint,
+			"page":     pages,
+		})
+		options = next
+	}
+}
+
+// validatePagination checks the settings that a pagination type requires
+func validatePagination(pagination *PaginationModel) error {
+	switch pagination.Type.ValueString() {
+	case paginationNextURL:
+		if pagination.NextPath.IsNull() || pagination.NextPath.IsUnknown() {
+			return fmt.Errorf("pagination type %q requires next_path", paginationNextURL)
+		}
+	case paginationCursor:
+		if pagination.CursorPath.IsNull() || pagination.CursorPath.IsUnknown() || pagination.CursorParam.IsNull() || pagination.CursorParam.IsUnknown() {
+			return fmt.Errorf("pagination type %q requires cursor_path and cursor_param", paginationCursor)
+		}
+	}
+	return nil
+}
+
+// start returns the request options of the first page
+func (p *pager) start() client.RequestOptions {
+	p.number = 1
+	if !p.pagination.FirstPage.IsNull() && !p.pagination.FirstPage.IsUnknown() {
+		p.number = p.pagination.FirstPage.ValueInt64()
+	}
+
+	switch p.pagination.Type.ValueString() {
+	case paginationOffset:
+		return p.withQuery(p.first, paginationParam(p.pagination.OffsetParam, "offset"), "0")
+	case paginationPage:
+		return p.withQuery(p.first, paginationParam(p.pagination.PageParam, "page"), strconv.FormatInt(p.number, 10))
+	default:
+		return p.withQuery(p.first, "", "")
+	}
+}
+
+// next returns the request options of the page after page, or false when page
+// is the last one
+func (p *pager) next(current client.RequestOptions, page *client.Response) (client.RequestOptions, bool, error) {
+	pagination := p.pagination
+
+	switch pagination.Type.ValueString() {
+	case paginationNextURL, paginationCursor:
+		path := pagination.NextPath.ValueString()
+		if pagination.Type.ValueString() == paginationCursor {
+			path = pagination.CursorPath.ValueString()
+		}
+		document, err := decodeJSONDocument(page.Body)
+		if err != nil {
+			return current, false, fmt.Errorf("page response is not JSON: %w", err)
+		}
+		value, found, err := lookupJSONPath(document, path)
+		if err != nil {
+			return current, false, fmt.Errorf("invalid pagination path: %w", err)
+		}
+		token := ""
+		if found && value != nil {
+			token, _ = jsonScalarString(value)
+		}
+		if token == "" {
+			return current, false, nil
+		}
+		if pagination.Type.ValueString() == paginationCursor {
+			return p.withQuery(p.first, pagination.CursorParam.ValueString(), token), true, nil
+		}
+		return p.followURL(current, page, token)
+
+	case paginationOffset, paginationPage:
+		items, err := pageItems(page.Body, p.itemsPath)
+		if err != nil {
+			return current, false, err
+		}
+		count := int64(len(items))
+		if count == 0 || (!pagination.PageSize.IsNull() && count < pagination.PageSize.ValueInt64()) {
+			return current, false, nil
+		}
+		if pagination.Type.ValueString() == paginationOffset {
+			p.offset += count
+			return p.withQuery(p.first, paginationParam(pagination.OffsetParam, "offset"), strconv.FormatInt(p.offset, 10)), true, nil
+		}
+		p.number++
+		return p.withQuery(p.first, paginationParam(pagination.PageParam, "page"), strconv.FormatInt(p.number, 10)), true, nil
+
+	default:
+		link := linkRelation(http.Header(page.Headers).Values("Link"), "next")
+		if link == "" {
+			return current, false, nil
+		}
+		return p.followURL(current, page, link)
+	}
+}
+
+// followURL returns the options for a next page URL given by the server,
+// resolved against the page URL
+func (p *pager) followURL(current client.RequestOptions, page *client.Response, next string) (client.RequestOptions, bool, error) {
+	resolved, err := p.client.ResolveLocation(page, next)
+	if err != nil {
+		return current, false, fmt.Errorf("invalid next page URL: %w", err)
+	}
+
+	// The next page URL is complete as given; the first request's query
+	// parameters must not be added to it again
+	current.Endpoint = resolved.String()
+	current.QueryParams = nil
+	current.QueryValues = nil
+	current.RawQuery = ""
+	return current, true, nil
+}
+
+// withQuery returns a copy of options with the page size and the given query
+// parameter set, replacing configured values of the same name
+func (p *pager) withQuery(options client.RequestOptions, name, value string) client.RequestOptions {
+	params := make(map[string]string, len(options.QueryParams)+2)
+	for key, existing := range options.QueryParams {
+		params[key] = existing
+	}
+	if !p.pagination.PageSize.IsNull() && !p.pagination.PageSize.IsUnknown() {
+		params[paginationParam(p.pagination.LimitParam, "limit")] = strconv.FormatInt(p.pagination.PageSize.ValueInt64(), 10)
+	}
+	if name != "" {
+		params[name] = value
+	}
+	if len(params) > 0 {
+		options.QueryParams = params
+	}
+	return options
+}
+
+// delay returns how long to wait before requesting the page after page: the
+// configured delay, or longer when the server asks for it
+func (p *pager) delay(page *client.Response) time.Duration {
+	wait := time.Duration(0)
+	if !p.pagination.DelayMs.IsNull() && !p.pagination.DelayMs.IsUnknown() {
+		wait = time.Duration(p.pagination.DelayMs.ValueInt64()) * time.Millisecond
+	}
+
+	headers := http.Header(page.Headers)
+	if retryAfter, ok := parseRetryAfter(headers.Get("Retry-After")); ok && retryAfter > wait {
+		wait = retryAfter
+	}
+	if reset, ok := rateLimitReset(headers); ok && reset > wait {
+		wait = reset
+	}
+	return wait
+}
+
+// sleep waits before the next request unless the context ends first
+func (p *pager) sleep(ctx context.Context, wait time.Duration, endpoint string) error {
+	if wait <= 0 {
+		return nil
+	}
+	tflog.Debug(ctx, "waiting before the next page", map[string]interface{}{
+		"endpoint": endpoint,
+		"wait":     wait.String(),
+	})
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(wait):
+		return nil
+	}
+}
+
+// rateLimitReset returns the time until the rate limit window resets when the
+// remaining request budget is exhausted. Reset values are accepted as seconds
+// to wait or as a Unix timestamp.
+func rateLimitReset(headers http.Header) (time.Duration, bool) {
+	for _, prefix := range []string{"X-RateLimit-", "RateLimit-"} {
+		remaining := headers.Get(prefix + "Remaining")
+		if remaining == "" {
+			continue
+		}
+		if count, err := strconv.Atoi(strings.TrimSpace(remaining)); err != nil || count > 0 {
+			return 0, false
+		}
+
+		reset, err := strconv.ParseInt(strings.TrimSpace(headers.Get(prefix+"Reset")), 10, 64)
+		if err != nil || reset < 0 {
+			return 0, false
+		}
+		// Values this large are timestamps rather than durations
+		if reset > 1_000_000_000 {
+			wait := time.Until(time.Unix(reset, 0))
+			if wait < 0 {
+				wait = 0
+			}
+			return wait, true
+		}
+		return time.Duration(reset) * time.Second, true
+	}
+	return 0, false
+}
+
+// paginationParam returns a configured query parameter name or its default
+func paginationParam(value types.String, fallback string) string {
+	if value.IsNull() || value.IsUnknown() || value.ValueString() == "" {
+		return fallback
+	}
+	return value.ValueString()
+}
+
+// pageItems returns the elements of the array at itemsPath in a page body. An
+// empty itemsPath means the body itself is the array.
+func pageItems(body []byte, itemsPath string) ([]interface{}, error) {
+	document, err := decodeJSONDocument(body)
+	if err != nil {
+		return nil, fmt.Errorf("response is not JSON: %w", err)
+	}
+	items, found, err := lookupJSONPath(document, itemsPath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid items_path: %w", err)
+	}
+	elements, ok := items.([]interface{})
+	if !found || !ok {
+		return nil, fmt.Errorf("no array found at items_path %q", itemsPath)
+	}
+	return elements, nil
+}
+
+// linkRelation returns the target of the first Link header entry (RFC 8288)
+// with the given relation type, or an empty string when there is none
+func linkRelation(values []string, relation string) string {
+	for _, value := range values {
+		for value != "" {
+			start := strings.Index(value, "<")
+			end := strings.Index(value, ">")
+			if start < 0 || end < start {
+				break
+			}
+			target := value[start+1 : end]
+
+			params := value[end+1:]
+			if next := strings.Index(params, "<"); next >= 0 {
+				value = params[next:]
+				params = params[:next]
+			} else {
+				value = ""
+			}
+
+			for _, param := range strings.Split(params, ";") {
+				name, rel, ok := strings.Cut(strings.TrimSpace(param), "=")
+				if !ok || !strings.EqualFold(strings.TrimSpace(name), "rel") {
+					continue
+				}
+				// rel may list several space-separated relation types
+				for _, candidate := range strings.Fields(strings.Trim(strings.TrimSpace(rel), `",`)) {
+					if strings.EqualFold(candidate, relation) {
+						return target
+					}
+				}
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,316 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-rest/internal/client"
+)
+
+func TestLinkRelation(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []string
+		expected string
+	}{
+		{name: "no header"},
+		{
+			name:     "single entry",
+			values:   []string{`<https://api.example.com/items?page=2>; rel="next"`},
+			expected: "https://api.example.com/items?page=2",
+		},
+		{
+			name:     "several entries",
+			values:   []string{`</items?page=1>; rel="prev", </items?page=3>; rel="next", </items?page=9>; rel="last"`},
+			expected: "/items?page=3",
+		},
+		{
+			name:     "several header lines and unquoted rel",
+			values:   []string{`</items?page=1>; rel=first`, `</items?page=2>; rel=next`},
+			expected: "/items?page=2",
+		},
+		{
+			name:     "multiple relation types",
+			values:   []string{`</items?cursor=a,b>; title="x"; rel="next last"`},
+			expected: "/items?cursor=a,b",
+		},
+		{
+			name:   "last page",
+			values: []string{`</items?page=1>; rel="first", </items?page=1>; rel="prev"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if next := linkRelation(tt.values, "next"); next != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, next)
+			}
+		})
+	}
+}
+
+func TestFetchPages(t *testing.T) {
+	tests := []struct {
+		name       string
+		pagination *PaginationModel
+		pages      int
+		stopAt     int
+		expected   int
+		limitErr   bool
+	}{
+		{
+			name:     "without pagination",
+			pages:    3,
+			expected: 1,
+		},
+		{
+			name:       "link header",
+			pagination: &PaginationModel{Type: types.StringNull(), NextPath: types.StringNull(), MaxPages: types.Int64Null()},
+			pages:      3,
+			expected:   3,
+		},
+		{
+			name:       "next url in body",
+			pagination: &PaginationModel{Type: types.StringValue(paginationNextURL), NextPath: types.StringValue("links.next"), MaxPages: types.Int64Null()},
+			pages:      3,
+			expected:   3,
+		},
+		{
+			name:       "visit stops early",
+			pagination: &PaginationModel{Type: types.StringNull(), NextPath: types.StringNull(), MaxPages: types.Int64Null()},
+			pages:      3,
+			stopAt:     2,
+			expected:   2,
+		},
+		{
+			name:       "max pages",
+			pagination: &PaginationModel{Type: types.StringNull(), NextPath: types.StringNull(), MaxPages: types.Int64Value(2)},
+			pages:      3,
+			expected:   2,
+			limitErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				page := 1
+				_, _ = fmt.Sscanf(r.URL.Query().Get("page"), "%d", &page)
+				if r.URL.Query().Get("filter") != "active" {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				next := "null"
+				if page < tt.pages {
+					w.Header().Set("Link", fmt.Sprintf(`</items?filter=active&page=%d>; rel="next"`, page+1))
+					next = fmt.Sprintf(`"/items?filter=active&page=%d"`, page+1)
+				}
+				_, _ = fmt.Fprintf(w, `{"page": %d, "links": {"next": %s}}`, page, next)
+			}))
+			defer server.Close()
+
+			restClient, err := client.NewRestClient(client.Config{BaseURL: server.URL})
+			if err != nil {
+				t.Fatalf("Failed to create client: %s", err)
+			}
+			options := client.RequestOptions{Method: "GET", Endpoint: "/items", QueryParams: map[string]string{"filter": "active"}}
+
+			visited := 0
+			err = fetchPages(context.Background(), restClient, options, defaultStatusPolicy("create"), tt.pagination, "", func(page *client.Response, decision statusDecision) (bool, error) {
+				if page.StatusCode != http.StatusOK {
+					return false, fmt.Errorf("page returned %d", page.StatusCode)
+				}
+				visited++
+				return visited == tt.stopAt, nil
+			})
+
+			var limit *pageLimitError
+			if tt.limitErr != errors.As(err, &limit) || (!tt.limitErr && err != nil) {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if visited != tt.expected {
+				t.Errorf("Expected %d pages, visited %d", tt.expected, visited)
+			}
+		})
+	}
+}
+
+func TestFetchPages_Types(t *testing.T) {
+	collection := []string{"a", "b", "c", "d", "e"}
+
+	tests := []struct {
+		name       string
+		pagination *PaginationModel
+		requests   int
+	}{
+		{
+			name:       "cursor",
+			pagination: &PaginationModel{Type: types.StringValue(paginationCursor), CursorPath: types.StringValue("meta.next"), CursorParam: types.StringValue("after"), PageSize: types.Int64Value(2)},
+			requests:   3,
+		},
+		{
+			name:       "offset",
+			pagination: &PaginationModel{Type: types.StringValue(paginationOffset), PageSize: types.Int64Value(2)},
+			requests:   3,
+		},
+		{
+			name:       "offset without page size ends at an empty page",
+			pagination: &PaginationModel{Type: types.StringValue(paginationOffset), OffsetParam: types.StringValue("skip")},
+			requests:   4,
+		},
+		{
+			name:       "page",
+			pagination: &PaginationModel{Type: types.StringValue(paginationPage), PageParam: types.StringValue("p"), FirstPage: types.Int64Value(0), LimitParam: types.StringValue("per_page"), PageSize: types.Int64Value(2)},
+			requests:   3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				query := r.URL.Query()
+				if query.Get("filter") != "active" {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+
+				// Without a page size, the server answers pages of two
+				size := 2
+				_, _ = fmt.Sscanf(query.Get("limit")+query.Get("per_page"), "%d", &size)
+				start := 0
+				switch {
+				case query.Has("after"):
+					_, _ = fmt.Sscanf(query.Get("after"), "%d", &start)
+				case query.Has("offset"):
+					_, _ = fmt.Sscanf(query.Get("offset"), "%d", &start)
+				case query.Has("skip"):
+					_, _ = fmt.Sscanf(query.Get("skip"), "%d", &start)
+				case query.Has("p"):
+					_, _ = fmt.Sscanf(query.Get("p"), "%d", &start)
+					start *= size
+				}
+				end := min(start+size, len(collection))
+				start = min(start, end)
+
+				next := `""`
+				if end < len(collection) {
+					next = fmt.Sprintf(`"%d"`, end)
+				}
+				items, _ := json.Marshal(collection[start:end])
+				_, _ = fmt.Fprintf(w, `{"data": %s, "meta": {"next": %s}}`, items, next)
+			}))
+			defer server.Close()
+
+			restClient, err := client.NewRestClient(client.Config{BaseURL: server.URL})
+			if err != nil {
+				t.Fatalf("Failed to create client: %s", err)
+			}
+			options := client.RequestOptions{Method: "GET", Endpoint: "/items", QueryParams: map[string]string{"filter": "active"}}
+
+			var collected []string
+			err = fetchPages(context.Background(), restClient, options, defaultStatusPolicy("create"), tt.pagination, "data", func(page *client.Response, decision statusDecision) (bool, error) {
+				items, err := pageItems(page.Body, "data")
+				if err != nil {
+					return false, err
+				}
+				for _, item := range items {
+					collected = append(collected, item.(string))
+				}
+				return false, nil
+			})
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			if strings.Join(collected, ",") != "a,b,c,d,e" {
+				t.Errorf("Expected all elements in order, got %v", collected)
+			}
+			if requests != tt.requests {
+				t.Errorf("Expected %d requests, got %d", tt.requests, requests)
+			}
+		})
+	}
+}
+
+func TestFetchPages_RateLimit(t *testing.T) {
+	var times []time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		times = append(times, time.Now())
+		switch len(times) {
+		case 1:
+			// The budget is exhausted until the window resets
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", "1")
+			w.Header().Set("Link", `</items?page=2>; rel="next"`)
+			_, _ = w.Write([]byte(`[]`))
+		case 2:
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			_, _ = w.Write([]byte(`[]`))
+		}
+	}))
+	defer server.Close()
+
+	restClient, err := client.NewRestClient(client.Config{BaseURL: server.URL, RetryAttempts: 1})
+	if err != nil {
+		t.Fatalf("Failed to create client: %s", err)
+	}
+
+	visited := 0
+	err = fetchPages(context.Background(), restClient, client.RequestOptions{Method: "GET", Endpoint: "/items"}, defaultStatusPolicy("create"), &PaginationModel{}, "", func(page *client.Response, decision statusDecision) (bool, error) {
+		visited++
+		return false, nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if visited != 2 || len(times) != 3 {
+		t.Fatalf("Expected 2 pages in 3 requests, got %d pages in %d requests", visited, len(times))
+	}
+	if wait := times[1].Sub(times[0]); wait < 900*time.Millisecond {
+		t.Errorf("Expected to wait for the rate limit reset, waited %s", wait)
+	}
+	if wait := times[2].Sub(times[1]); wait < 900*time.Millisecond {
+		t.Errorf("Expected to wait for Retry-After, waited %s", wait)
+	}
+}
+
+func TestRateLimitReset(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers map[string]string
+		ok      bool
+		minimum time.Duration
+	}{
+		{name: "no headers"},
+		{name: "budget left", headers: map[string]string{"X-RateLimit-Remaining": "10", "X-RateLimit-Reset": "30"}},
+		{name: "seconds", headers: map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "30"}, ok: true, minimum: 30 * time.Second},
+		{name: "timestamp", headers: map[string]string{"RateLimit-Remaining": "0", "RateLimit-Reset": fmt.Sprint(time.Now().Add(time.Minute).Unix())}, ok: true, minimum: 58 * time.Second},
+		{name: "invalid reset", headers: map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "soon"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headers := http.Header{}
+			for key, value := range tt.headers {
+				headers.Set(key, value)
+			}
+			wait, ok := rateLimitReset(headers)
+			if ok != tt.ok || wait < tt.minimum || (ok && wait > tt.minimum+2*time.Second) {
+				t.Errorf("Expected %v and at least %s, got %v and %s", tt.ok, tt.minimum, ok, wait)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// expandPathTemplate substitutes {placeholder} references in a path template.
+// Values are escaped as a single path segment, so a value containing "/" or "?"
+// cannot change the shape of the URL. {+placeholder} inserts the value
+// verbatim for values that are already paths. The endpoint and self_link
+// placeholders are always inserted verbatim since they are paths by definition.
+func expandPathTemplate(template string, values map[string]string) (string, error) {
+	var result strings.Builder
+	rest := template
+
+	for {
+		start := strings.IndexByte(rest, '{')
+		if start < 0 {
+			result.WriteString(rest)
+			break
+		}
+		end := strings.IndexByte(rest[start:], '}')
+		if end < 0 {
+			return "", fmt.Errorf("unterminated placeholder in path template %q", template)
+		}
+		end += start
+
+		result.WriteString(rest[:start])
+		name := rest[start+1 : end]
+		rest = rest[end+1:]
+
+		verbatim := strings.HasPrefix(name, "+")
+		name = strings.TrimPrefix(name, "+")
+		if name == "" {
+			return "", fmt.Errorf("empty placeholder in path template %q", template)
+		}
+
+		value, ok := values[name]
+		if !ok {
+			return "", fmt.Errorf("placeholder {%s} in path template %q has no value; use id, name, endpoint, self_link or a top-level field of the response", name, template)
+		}
+
+		if verbatim || name == "endpoint" || name == "self_link" {
+			result.WriteString(value)
+		} else {
+			result.WriteString(url.PathEscape(value))
+		}
+	}
+
+	return result.String(), nil
+}
+
+// escapePathSegments escapes each "/"-separated segment of a path individually,
+// keeping the separators intact.
+func escapePathSegments(value string) string {
+	segments := strings.Split(value, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
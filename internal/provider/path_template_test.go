@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"testing"
+)
+
+func TestExpandPathTemplate(t *testing.T) {
+	values := map[string]string{
+		"id":       "a/b c",
+		"name":     "widget",
+		"endpoint": "/api/v1/items",
+		"org":      "acme",
+		"parent":   "folders/7",
+	}
+
+	tests := []struct {
+		name      string
+		template  string
+		expected  string
+		expectErr bool
+	}{
+		{name: "no placeholders", template: "/items/fixed", expected: "/items/fixed"},
+		{name: "id is escaped", template: "/items/{id}", expected: "/items/a%2Fb%20c"},
+		{name: "multiple placeholders", template: "/orgs/{org}/items/{name}", expected: "/orgs/acme/items/widget"},
+		{name: "custom method suffix", template: "/orgs/{org}/items/{name}:update", expected: "/orgs/acme/items/widget:update"},
+		{name: "endpoint is verbatim", template: "{endpoint}/{name}", expected: "/api/v1/items/widget"},
+		{name: "reserved expansion", template: "/{+parent}/items", expected: "/folders/7/items"},
+		{name: "unknown placeholder", template: "/items/{missing}", expectErr: true},
+		{name: "unterminated placeholder", template: "/items/{id", expectErr: true},
+		{name: "empty placeholder", template: "/items/{}", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := expandPathTemplate(tt.template, values)
+
+			if tt.expectErr {
+				if err == nil {
+					t.Errorf("Expected error for template %q, got %q", tt.template, result)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if result != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,429 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"terraform-provider-rest/internal/client"
+)
+
+const (
+	defaultPollInterval = 5 * time.Second
+	defaultPollTimeout  = 10 * time.Minute
+)
+
+// PollModel describes how to follow a long-running operation started by a
+// 202 Accepted response.
+type PollModel struct {
+	UrlHeader      types.String   `tfsdk:"url_header"`
+	UrlPath        types.String   `tfsdk:"url_path"`
+	StatusPath     types.String   `tfsdk:"status_path"`
+	SuccessValues  []types.String `tfsdk:"success_values"`
+	FailureValues  []types.String `tfsdk:"failure_values"`
+	PendingValues  []types.String `tfsdk:"pending_values"`
+	Interval       types.Int64    `tfsdk:"interval"`
+	Timeout        types.Int64    `tfsdk:"timeout"`
+	ReadAfter      types.Bool     `tfsdk:"read_after"`
+	ResultIdPath   types.String   `tfsdk:"result_id_path"`
+	ResultLinkPath types.String   `tfsdk:"result_link_path"`
+}
+
+// pollAttribute returns the schema for the poll block shared by create, update
+// and delete operations.
+func pollAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "Follow long-running operations. When a create, update or delete request returns `202 Accepted`, the operation URL is polled with GET until its status reaches a success or failure value.",
+		Optional:            true,
+		Attributes: map[string]schema.Attribute{
+			"url_header": schema.StringAttribute{
+				MarkdownDescription: "Response header holding the operation URL, e.g. `Operation-Location`. Default: `Operation-Location`, then `Location`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("url_path")),
+				},
+			},
+			"url_path": schema.StringAttribute{
+				MarkdownDescription: "JSON path or pointer to the operation URL in the `202` response body, e.g. `operation.href`.",
+				Optional:            true,
+			},
+			"status_path": schema.StringAttribute{
+				MarkdownDescription: "JSON path or pointer to the status in the operation response, e.g. `status` or `/metadata/state`.",
+				Required:            true,
+			},
+			"success_values": schema.ListAttribute{
+				MarkdownDescription: "Status values that mean the operation succeeded, e.g. `[\"DONE\"]`.",
+				ElementType:         types.StringType,
+				Required:            true,
+			},
+			"failure_values": schema.ListAttribute{
+				MarkdownDescription: "Status values that mean the operation failed.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"pending_values": schema.ListAttribute{
+				MarkdownDescription: "Status values that mean the operation is still running. When set, any other value is an error; otherwise every value that is neither a success nor a failure keeps polling.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"interval": schema.Int64Attribute{
+				MarkdownDescription: "Seconds between polls. A `Retry-After` header on the operation response takes precedence. Default: 5.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"timeout": schema.Int64Attribute{
+				MarkdownDescription: "Maximum seconds to wait for the operation to finish. Default: 600.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"read_after": schema.BoolAttribute{
+				MarkdownDescription: "After a create or update operation succeeds, read the object again so state reflects its final form rather than the `202` response. Default: true.",
+				Optional:            true,
+			},
+			"result_id_path": schema.StringAttribute{
+				MarkdownDescription: "JSON path or pointer to the ID of the created object in the final operation response, e.g. `result.id`. Used on create when the `202` response carries no ID.",
+				Optional:            true,
+			},
+			"result_link_path": schema.StringAttribute{
+				MarkdownDescription: "JSON path or pointer to the URL of the created object in the final operation response, e.g. `targetLink`. On create it becomes `self_link`, so later operations and the read after the operation use it.",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+// shouldPoll reports whether a response starts a long-running operation that the
+// poll block should follow
+func (r *RestResource) shouldPoll(data *RestResourceModel, response *client.Response) bool {
+	return data.Poll != nil && response.StatusCode == http.StatusAccepted
+}
+
+// pollOperation follows the operation started by response as described by poll
+// until it reaches a success value, returning the final operation response.
+// Failure values, unexpected values and the timeout are errors.
+func (r *RestResource) pollOperation(ctx context.Context, data *RestResourceModel, poll *PollModel, response *client.Response) (*client.Response, error) {
+	operationURL, err := r.operationURL(poll, response)
+	if err != nil {
+		return nil, err
+	}
+
+	interval := defaultPollInterval
+	if !poll.Interval.IsNull() {
+		interval = time.Duration(poll.Interval.ValueInt64()) * time.Second
+	}
+	timeout := defaultPollTimeout
+	if !poll.Timeout.IsNull() {
+		timeout = time.Duration(poll.Timeout.ValueInt64()) * time.Second
+	}
+	deadline := time.Now().Add(timeout)
+
+	options := r.buildRequestOptions(ctx, data, "GET", "")
+	options.Endpoint = operationURL
+	// The operation URL is complete as given; the object's query and response
+	// handling settings do not apply to it
+	options.QueryParams = nil
+	options.QueryValues = nil
+	options.RawQuery = ""
+	options.StreamResponse = false
+	options.SpoolPath = ""
+
+	statusPath := poll.StatusPath.ValueString()
+	success := stringSet(poll.SuccessValues)
+	failure := stringSet(poll.FailureValues)
+	pending := stringSet(poll.PendingValues)
+
+	for attempt := 1; ; attempt++ {
+		current, err := r.client.Do(ctx, options)
+		if err != nil {
+			return nil, fmt.Errorf("polling operation %s: %w", operationURL, err)
+		}
+		if current.StatusCode < 200 || current.StatusCode >= 300 {
+			return nil, fmt.Errorf("polling operation %s returned status %d: %s", operationURL, current.StatusCode, string(current.Body))
+		}
+
+		document, err := decodeJSONDocument(current.Body)
+		if err != nil {
+			return nil, fmt.Errorf("operation response from %s is not JSON: %w", operationURL, err)
+		}
+		value, found, err := lookupJSONPath(document, statusPath)
+		if err != nil {
+			return nil, fmt.Errorf("invalid poll status_path: %w", err)
+		}
+		status := ""
+		if found {
+			status, _ = jsonScalarString(value)
+		}
+
+		tflog.Debug(ctx, "polled operation", map[string]interface{}{
+			"url":     operationURL,
+			"attempt": attempt,
+			"status":  status,
+		})
+
+		switch {
+		case success[status]:
+			return current, nil
+		case failure[status]:
+			return nil, fmt.Errorf("operation %s failed with status %q: %s", operationURL, status, string(current.Body))
+		case len(pending) > 0 && !pending[status]:
+			return nil, fmt.Errorf("operation %s returned unexpected status %q: %s", operationURL, status, string(current.Body))
+		}
+
+		wait := interval
+		if retryAfter, ok := parseRetryAfter(http.Header(current.Headers).Get("Retry-After")); ok {
+			wait = retryAfter
+		}
+		if time.Now().Add(wait).After(deadline) {
+			return nil, fmt.Errorf("timed out after %s waiting for operation %s; last status %q", timeout, operationURL, status)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// operationURL locates the operation URL in a 202 response and resolves it
+// against the request URL
+func (r *RestResource) operationURL(poll *PollModel, response *client.Response) (string, error) {
+	var location string
+
+	switch {
+	case !poll.UrlPath.IsNull():
+		document, err := decodeJSONDocument(response.Body)
+		if err != nil {
+			return "", fmt.Errorf("cannot read poll url_path: the 202 response body is not JSON: %w", err)
+		}
+		value, found, err := lookupJSONPath(document, poll.UrlPath.ValueString())
+		if err != nil {
+			return "", fmt.Errorf("invalid poll url_path: %w", err)
+		}
+		if found {
+			location, _ = jsonScalarString(value)
+		}
+		if location == "" {
+			return "", fmt.Errorf("the 202 response has no operation URL at %q", poll.UrlPath.ValueString())
+		}
+
+	case !poll.UrlHeader.IsNull():
+		location = http.Header(response.Headers).Get(poll.UrlHeader.ValueString())
+		if location == "" {
+			return "", fmt.Errorf("the 202 response has no %s header", poll.UrlHeader.ValueString())
+		}
+
+	default:
+		headers := http.Header(response.Headers)
+		location = headers.Get("Operation-Location")
+		if location == "" {
+			location = headers.Get("Location")
+		}
+		if location == "" {
+			return "", fmt.Errorf("the 202 response has no Operation-Location or Location header; set poll url_header or url_path")
+		}
+	}
+
+	resolved, err := r.client.ResolveLocation(response, location)
+	if err != nil {
+		return "", err
+	}
+	return resolved.String(), nil
+}
+
+// completeOperation polls the operation started by response and, when refresh is
+// set and read_after allows it, reads the finished object into the model.
+//
+// While the object has no ID yet, as on create, the 202 response describes the
+// operation rather than the object. The ID is then taken from a configured
+// id_from_header or id_attribute on the 202 response, from result_id_path and
+// result_link_path in the final operation response, or from the read after the
+// operation; the caller reports an ID that is still missing.
+func (r *RestResource) completeOperation(ctx context.Context, data *RestResourceModel, response *client.Response, refresh bool) error {
+	creating := data.Id.IsNull() || data.Id.IsUnknown()
+	if creating && (data.IdFromHeader != nil || !data.IdAttribute.IsNull()) {
+		// The object may already be named in the 202 response; if not, the
+		// operation result or the read after it can still provide the ID
+		if id, found, err := r.responseID(data, response); err == nil && found {
+			data.Id = types.StringValue(id)
+		}
+	}
+
+	result, err := r.pollOperation(ctx, data, data.Poll, response)
+	if err != nil {
+		return err
+	}
+	if creating {
+		if err := r.recordOperationResult(ctx, data, result); err != nil {
+			return err
+		}
+	}
+
+	if !refresh || (!data.Poll.ReadAfter.IsNull() && !data.Poll.ReadAfter.ValueBool()) {
+		return nil
+	}
+	if !r.objectAddressable(data) {
+		tflog.Debug(ctx, "not reading the object after the operation: it has no ID, name or self_link yet")
+		return nil
+	}
+	return r.refreshObject(ctx, data)
+}
+
+// recordOperationResult takes the ID and URL of a created object from the final
+// operation response as configured by result_id_path and result_link_path
+func (r *RestResource) recordOperationResult(ctx context.Context, data *RestResourceModel, result *client.Response) error {
+	poll := data.Poll
+	if poll.ResultIdPath.IsNull() && poll.ResultLinkPath.IsNull() {
+		return nil
+	}
+
+	document, err := decodeJSONDocument(result.Body)
+	if err != nil {
+		return fmt.Errorf("cannot read the operation result: the response is not JSON: %w", err)
+	}
+
+	if !poll.ResultIdPath.IsNull() {
+		id, err := operationResultValue(document, "result_id_path", poll.ResultIdPath.ValueString())
+		if err != nil {
+			return err
+		}
+		if data.Id.IsNull() || data.Id.IsUnknown() {
+			data.Id = types.StringValue(id)
+			tflog.Debug(ctx, "extracted ID from operation result", map[string]interface{}{
+				"id": id,
+			})
+		}
+	}
+
+	if !poll.ResultLinkPath.IsNull() {
+		link, err := operationResultValue(document, "result_link_path", poll.ResultLinkPath.ValueString())
+		if err != nil {
+			return err
+		}
+		selfLink, err := r.client.ResolveLocation(result, link)
+		if err != nil {
+			return err
+		}
+		data.SelfLink = types.StringValue(selfLink.String())
+	}
+
+	return nil
+}
+
+// operationResultValue returns the non-empty scalar at path in an operation
+// response
+func operationResultValue(document interface{}, attribute, path string) (string, error) {
+	value, found, err := lookupJSONPath(document, path)
+	if err != nil {
+		return "", fmt.Errorf("invalid poll %s: %w", attribute, err)
+	}
+	text := ""
+	if found {
+		text, _ = jsonScalarString(value)
+	}
+	if text == "" {
+		return "", fmt.Errorf("the operation result has no value at %s %q", attribute, path)
+	}
+	return text, nil
+}
+
+// objectAddressable reports whether the object's read request can be built yet
+func (r *RestResource) objectAddressable(data *RestResourceModel) bool {
+	if data.ReadFromList != nil {
+		_, key := listMatchKey(data)
+		return key != ""
+	}
+	_, err := r.operationPath(data, "read")
+	return err == nil
+}
+
+// refreshObject reads the object at its read path and records the response in
+// the model
+func (r *RestResource) refreshObject(ctx context.Context, data *RestResourceModel) error {
+	response, decision, endpoint, err := r.readObject(ctx, data)
+	if err != nil {
+		return fmt.Errorf("reading the object after the operation finished: %w", err)
+	}
+	if decision.outcome == statusGone || checkFailedStatus(ctx, response, decision) != nil {
+		return fmt.Errorf("reading %s after the operation finished returned status %d: %s", endpoint, response.StatusCode, string(response.Body))
+	}
+
+	return r.processResponse(ctx, response, data)
+}
+
+// readObject sends the read request for the object, retrying as the read
+// status policy asks. It returns the response, the policy decision for it and
+// the path it was sent to; acting on the decision is left to the caller.
+func (r *RestResource) readObject(ctx context.Context, data *RestResourceModel) (*client.Response, statusDecision, string, error) {
+	if data.ReadFromList != nil {
+		return r.readFromList(ctx, data)
+	}
+
+	endpoint, err := r.operationPath(data, "read")
+	if err != nil {
+		return nil, statusDecision{}, "", err
+	}
+
+	policy, err := r.statusPolicy(ctx, data, "read")
+	if err != nil {
+		return nil, statusDecision{}, endpoint, err
+	}
+
+	method := r.resolveMethodForOperation(data, "read")
+	options := r.buildRequestOptions(ctx, data, method, "")
+	options.Endpoint = endpoint
+	// The steps reading on behalf of others inspect the body, and must not
+	// replace the spooled response of the last refresh
+	options.StreamResponse = false
+	options.SpoolPath = ""
+
+	response, decision, err := doWithStatusPolicy(ctx, r.client, options, policy)
+	if err != nil {
+		return nil, statusDecision{}, endpoint, err
+	}
+	return response, decision, endpoint, nil
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		wait := time.Until(at)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+// stringSet converts a list of strings into a set for membership checks
+func stringSet(values []types.String) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		if !value.IsNull() && !value.IsUnknown() {
+			set[value.ValueString()] = true
+		}
+	}
+	return set
+}
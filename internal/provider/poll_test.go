@@ -0,0 +1,263 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"terraform-provider-rest/internal/client"
+)
+
+func TestRestResource_CompleteOperation(t *testing.T) {
+	tests := []struct {
+		name         string
+		statuses     []string
+		poll         PollModel
+		errMsg       string
+		expectedPoll int32
+	}{
+		{
+			name:         "succeeds after pending",
+			statuses:     []string{"RUNNING", "RUNNING", "DONE"},
+			poll:         PollModel{StatusPath: types.StringValue("status"), SuccessValues: []types.String{types.StringValue("DONE")}},
+			expectedPoll: 3,
+		},
+		{
+			name:     "failure value",
+			statuses: []string{"RUNNING", "FAILED"},
+			poll: PollModel{
+				StatusPath:    types.StringValue("status"),
+				SuccessValues: []types.String{types.StringValue("DONE")},
+				FailureValues: []types.String{types.StringValue("FAILED")},
+			},
+			errMsg:       `failed with status "FAILED"`,
+			expectedPoll: 2,
+		},
+		{
+			name:     "unexpected value with pending values",
+			statuses: []string{"RUNNING", "EXPLODED"},
+			poll: PollModel{
+				StatusPath:    types.StringValue("status"),
+				SuccessValues: []types.String{types.StringValue("DONE")},
+				PendingValues: []types.String{types.StringValue("RUNNING")},
+			},
+			errMsg:       `unexpected status "EXPLODED"`,
+			expectedPoll: 2,
+		},
+		{
+			name:     "body url path",
+			statuses: []string{"DONE"},
+			poll: PollModel{
+				UrlPath:       types.StringValue("operation.href"),
+				StatusPath:    types.StringValue("status"),
+				SuccessValues: []types.String{types.StringValue("DONE")},
+			},
+			expectedPoll: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var polls int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/api/items":
+					w.Header().Set("Operation-Location", "/api/operations/1")
+					w.WriteHeader(http.StatusAccepted)
+					_, _ = w.Write([]byte(`{"operation": {"href": "operations/1"}}`))
+				case "/api/operations/1":
+					n := atomic.AddInt32(&polls, 1)
+					w.Header().Set("Retry-After", "0")
+					_, _ = w.Write([]byte(`{"status": "` + tt.statuses[n-1] + `"}`))
+				case "/api/items/42":
+					_, _ = w.Write([]byte(`{"id": "42", "state": "ready"}`))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			restClient, err := client.NewRestClient(client.Config{BaseURL: server.URL + "/api"})
+			if err != nil {
+				t.Fatalf("Failed to create client: %s", err)
+			}
+			r := &RestResource{client: restClient}
+
+			poll := tt.poll
+			data := &RestResourceModel{
+				Endpoint: types.StringValue("/items"),
+				Id:       types.StringValue("42"),
+				Poll:     &poll,
+			}
+
+			response, err := restClient.Do(context.Background(), client.RequestOptions{Method: "POST", Endpoint: "/items"})
+			if err != nil {
+				t.Fatalf("Request failed: %s", err)
+			}
+			if !r.shouldPoll(data, response) {
+				t.Fatalf("Expected a 202 response to be polled")
+			}
+
+			err = r.completeOperation(context.Background(), data, response, true)
+
+			if polls != tt.expectedPoll {
+				t.Errorf("Expected %d polls, got %d", tt.expectedPoll, polls)
+			}
+
+			if tt.errMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+					t.Errorf("Expected error containing %q, got %v", tt.errMsg, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			state, ok := data.ResponseData.Elements()["state"].(types.String)
+			if !ok || state.ValueString() != "ready" {
+				t.Errorf("Expected the finished object to be read, got response data %v", data.ResponseData)
+			}
+		})
+	}
+}
+
+func TestRestResource_CreateWithOperation(t *testing.T) {
+	tests := []struct {
+		name             string
+		objectName       types.String
+		resultIdPath     types.String
+		resultLinkPath   types.String
+		expectedID       string
+		expectedSelfLink string
+		errMsg           string
+	}{
+		{
+			name:         "ID from the operation result",
+			objectName:   types.StringNull(),
+			resultIdPath: types.StringValue("result.id"),
+			expectedID:   "42",
+		},
+		{
+			name:             "link from the operation result",
+			objectName:       types.StringNull(),
+			resultLinkPath:   types.StringValue("result.href"),
+			expectedID:       "42",
+			expectedSelfLink: "/api/objects/42",
+		},
+		{
+			name:       "ID from the read after the operation",
+			objectName: types.StringValue("widget"),
+			expectedID: "42",
+		},
+		{
+			name:       "no ID anywhere",
+			objectName: types.StringNull(),
+			errMsg:     "no resource ID",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			var polls int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == "POST" && r.URL.Path == "/api/items":
+					// The 202 body describes the operation, not the object
+					w.Header().Set("Operation-Location", "/api/operations/op-1")
+					w.WriteHeader(http.StatusAccepted)
+					_, _ = w.Write([]byte(`{"id": "op-1", "status": "RUNNING"}`))
+				case r.URL.Path == "/api/operations/op-1":
+					atomic.AddInt32(&polls, 1)
+					_, _ = w.Write([]byte(`{"id": "op-1", "status": "DONE", "result": {"id": "42", "href": "/api/objects/42"}}`))
+				case r.URL.Path == "/api/objects/42", r.URL.Path == "/api/items/42", r.URL.Path == "/api/items/widget":
+					_, _ = w.Write([]byte(`{"id": "42", "state": "ready"}`))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			restClient, err := client.NewRestClient(client.Config{BaseURL: server.URL + "/api"})
+			if err != nil {
+				t.Fatalf("Failed to create client: %s", err)
+			}
+			r := &RestResource{client: restClient}
+
+			schemaResp := &resource.SchemaResponse{}
+			r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+			schemaType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+			attributes := make(map[string]tftypes.Value, len(schemaType.AttributeTypes))
+			for name, attributeType := range schemaType.AttributeTypes {
+				attributes[name] = tftypes.NewValue(attributeType, nil)
+			}
+			attributes["id"] = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+			plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaType, attributes)}
+
+			diags := plan.SetAttribute(ctx, path.Root("endpoint"), "/items")
+			diags.Append(plan.SetAttribute(ctx, path.Root("name"), tt.objectName)...)
+			diags.Append(plan.SetAttribute(ctx, path.Root("poll"), &PollModel{
+				StatusPath:     types.StringValue("status"),
+				SuccessValues:  []types.String{types.StringValue("DONE")},
+				ResultIdPath:   tt.resultIdPath,
+				ResultLinkPath: tt.resultLinkPath,
+			})...)
+			if diags.HasError() {
+				t.Fatalf("Failed to build plan: %v", diags)
+			}
+
+			resp := &resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+			r.Create(ctx, resource.CreateRequest{Plan: plan, Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw}}, resp)
+
+			if polls != 1 {
+				t.Errorf("Expected the operation to be polled once, got %d", polls)
+			}
+
+			if tt.errMsg != "" {
+				if !resp.Diagnostics.HasError() || !strings.Contains(resp.Diagnostics.Errors()[0].Detail(), tt.errMsg) {
+					t.Errorf("Expected error containing %q, got %v", tt.errMsg, resp.Diagnostics)
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Unexpected errors: %v", resp.Diagnostics)
+			}
+
+			var data RestResourceModel
+			if diags := resp.State.Get(ctx, &data); diags.HasError() {
+				t.Fatalf("Failed to read state: %v", diags)
+			}
+			if data.Id.ValueString() != tt.expectedID {
+				t.Errorf("Expected ID %q, got %s", tt.expectedID, data.Id)
+			}
+			if tt.expectedSelfLink != "" && data.SelfLink.ValueString() != server.URL+tt.expectedSelfLink {
+				t.Errorf("Expected self_link %s, got %s", server.URL+tt.expectedSelfLink, data.SelfLink)
+			}
+			state, ok := data.ResponseData.Elements()["state"].(types.String)
+			if !ok || state.ValueString() != "ready" {
+				t.Errorf("Expected the finished object to be read, got response data %v", data.ResponseData)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if wait, ok := parseRetryAfter("7"); !ok || wait.Seconds() != 7 {
+		t.Errorf("Expected 7s, got %s, %v", wait, ok)
+	}
+	if wait, ok := parseRetryAfter("Mon, 02 Jan 2006 15:04:05 GMT"); !ok || wait != 0 {
+		t.Errorf("Expected a past date to mean no wait, got %s, %v", wait, ok)
+	}
+	if _, ok := parseRetryAfter("soon"); ok {
+		t.Errorf("Expected an invalid value to be ignored")
+	}
+}
@@ -6,10 +6,12 @@ import (
 	"context"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"terraform-provider-rest/internal/client"
 )
@@ -116,6 +118,22 @@ func (p *restProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp
 				Optional:    true,
 				Description: "Maximum number of idle HTTP connections (default: 100).",
 			},
+			"request_compression": schema.StringAttribute{
+				Optional:    true,
+				Description: "Compress request bodies with 'gzip' or 'zstd' and set the Content-Encoding header (default: no compression).",
+				Validators: []validator.String{
+					stringvalidator.OneOf("gzip", "zstd"),
+				},
+			},
+			"max_response_bytes": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum size in bytes of a buffered response body (default: unlimited). Larger responses fail with a diagnostic.",
+			},
+			"allowed_hosts": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Additional hosts that absolute endpoint URLs and redirects may target, besides the api_url host. Entries match a hostname on any port, a host:port exactly, or any subdomain with a leading '*.' (e.g. '*.example.com'). When api_url uses https, http URLs are refused on every host.",
+			},
 		},
 	}
 }
@@ -124,20 +142,23 @@ func (p *restProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp
 func (p *restProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
 	// Extract provider configuration values
 	var config struct {
-		APIURL         types.String `tfsdk:"api_url"`
-		APIToken       types.String `tfsdk:"api_token"`
-		APIHeader      types.String `tfsdk:"api_header"`
-		ClientCert     types.String `tfsdk:"client_cert"`
-		ClientKey      types.String `tfsdk:"client_key"`
-		ClientCertFile types.String `tfsdk:"client_cert_file"`
-		ClientKeyFile  types.String `tfsdk:"client_key_file"`
-		PKCS12Bundle   types.String `tfsdk:"pkcs12_bundle"`
-		PKCS12File     types.String `tfsdk:"pkcs12_file"`
-		PKCS12Password types.String `tfsdk:"pkcs12_password"`
-		Timeout        types.Int64  `tfsdk:"timeout"`
-		Insecure       types.Bool   `tfsdk:"insecure"`
-		RetryAttempts  types.Int64  `tfsdk:"retry_attempts"`
-		MaxIdleConns   types.Int64  `tfsdk:"max_idle_conns"`
+		APIURL             types.String `tfsdk:"api_url"`
+		APIToken           types.String `tfsdk:"api_token"`
+		APIHeader          types.String `tfsdk:"api_header"`
+		ClientCert         types.String `tfsdk:"client_cert"`
+		ClientKey          types.String `tfsdk:"client_key"`
+		ClientCertFile     types.String `tfsdk:"client_cert_file"`
+		ClientKeyFile      types.String `tfsdk:"client_key_file"`
+		PKCS12Bundle       types.String `tfsdk:"pkcs12_bundle"`
+		PKCS12File         types.String `tfsdk:"pkcs12_file"`
+		PKCS12Password     types.String `tfsdk:"pkcs12_password"`
+		Timeout            types.Int64  `tfsdk:"timeout"`
+		Insecure           types.Bool   `tfsdk:"insecure"`
+		RetryAttempts      types.Int64  `tfsdk:"retry_attempts"`
+		MaxIdleConns       types.Int64  `tfsdk:"max_idle_conns"`
+		AllowedHosts       types.List   `tfsdk:"allowed_hosts"`
+		MaxResponseBytes   types.Int64  `tfsdk:"max_response_bytes"`
+		RequestCompression types.String `tfsdk:"request_compression"`
 	}
 
 	diags := req.Config.Get(ctx, &config)
@@ -235,6 +256,14 @@ func (p *restProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		insecure = config.Insecure.ValueBool()
 	}
 
+	var allowedHosts []string
+	if !config.AllowedHosts.IsNull() {
+		resp.Diagnostics.Append(config.AllowedHosts.ElementsAs(ctx, &allowedHosts, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	// Create the REST client configuration
 	clientConfig := client.Config{
 		BaseURL:       config.APIURL.ValueString(),
@@ -242,6 +271,13 @@ func (p *restProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		Insecure:      insecure,
 		RetryAttempts: retryAttempts,
 		MaxIdleConns:  maxIdleConns,
+		AllowedHosts:  allowedHosts,
+	}
+	if !config.MaxResponseBytes.IsNull() {
+		clientConfig.MaxResponseBytes = config.MaxResponseBytes.ValueInt64()
+	}
+	if !config.RequestCompression.IsNull() {
+		clientConfig.RequestCompression = config.RequestCompression.ValueString()
 	}
 
 	// Configure authentication based on the method chosen
@@ -300,5 +336,6 @@ func (p *restProvider) DataSources(_ context.Context) []func() datasource.DataSo
 func (p *restProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewRestResource,
+		NewRestActionResource,
 	}
 }
@@ -39,7 +39,7 @@ func TestRestProvider_Schema(t *testing.T) {
 	}
 
 	// Check that optional attributes are present
-	optionalAttrs := []string{"api_header", "timeout", "insecure", "retry_attempts", "max_idle_conns"}
+	optionalAttrs := []string{"api_header", "timeout", "insecure", "retry_attempts", "max_idle_conns", "allowed_hosts", "max_response_bytes", "request_compression"}
 	for _, attr := range optionalAttrs {
 		if _, exists := resp.Schema.Attributes[attr]; !exists {
 			t.Errorf("Expected optional attribute %s to exist", attr)
@@ -70,8 +70,8 @@ func TestRestProvider_Resources(t *testing.T) {
 	p := &restProvider{}
 	resources := p.Resources(context.Background())
 
-	if len(resources) != 1 {
-		t.Errorf("Expected 1 resource, got %d", len(resources))
+	if len(resources) != 2 {
+		t.Fatalf("Expected 2 resources, got %d", len(resources))
 	}
 
 	// Test that the resource can be created
@@ -83,6 +83,10 @@ func TestRestProvider_Resources(t *testing.T) {
 	if _, ok := resource.(*RestResource); !ok {
 		t.Errorf("Expected RestResource, got %T", resource)
 	}
+
+	if _, ok := resources[1]().(*RestActionResource); !ok {
+		t.Errorf("Expected RestActionResource, got %T", resources[1]())
+	}
 }
 
 func TestNew(t *testing.T) {
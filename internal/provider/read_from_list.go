@@ -0,0 +1,187 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"terraform-provider-rest/internal/client"
+)
+
+// ReadFromListModel describes how to find the object in a collection response
+// for APIs without a single-object read endpoint.
+type ReadFromListModel struct {
+	Path       types.String     `tfsdk:"path"`
+	ItemsPath  types.String     `tfsdk:"items_path"`
+	KeyField   types.String     `tfsdk:"key_field"`
+	MatchOn    types.String     `tfsdk:"match_on"`
+	Pagination *PaginationModel `tfsdk:"pagination"`
+}
+
+// readFromListAttribute returns the schema for the read_from_list block
+func readFromListAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "Read the object from a collection, for APIs that only offer `GET /items`. The collection is read page by page until the element whose `key_field` equals the object's ID (or name) is found; that element is then used as the read response for `response_data` and drift detection. An object missing from the collection is treated like a `404` response. Applies to refresh, `wait_for_ready`, `wait_for_delete` and `check_exists_before_create`.",
+		Optional:            true,
+		Attributes: map[string]schema.Attribute{
+			"path": schema.StringAttribute{
+				MarkdownDescription: "Path template of the collection, e.g. `/projects/{project_id}/items`. Default: `endpoint`.",
+				Optional:            true,
+			},
+			"items_path": schema.StringAttribute{
+				MarkdownDescription: "JSON path or pointer to the array of elements in each page, e.g. `data` or `$.result.items`. Default: the response body itself is the array.",
+				Optional:            true,
+			},
+			"key_field": schema.StringAttribute{
+				MarkdownDescription: "JSON path or pointer, relative to each element, of the value to match, e.g. `uuid` or `metadata.name`. Default: `id` or `name`, following `match_on`.",
+				Optional:            true,
+			},
+			"match_on": schema.StringAttribute{
+				MarkdownDescription: "Which stored value `key_field` must equal: `id` (default) or `name`. Matching on `id` falls back to the name while the ID is not yet known.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("id", "name"),
+				},
+			},
+			"pagination": resourcePaginationAttribute(),
+		},
+	}
+}
+
+// readFromList reads the object from its collection as configured by
+// read_from_list. The returned response carries the matching element as its
+// body, the collection response when the read status policy does not accept
+// it, or a 404 when no element matched, together with its read status policy
+// decision.
+func (r *RestResource) readFromList(ctx context.Context, data *RestResourceModel) (*client.Response, statusDecision, string, error) {
+	list := data.ReadFromList
+
+	endpoint := data.Endpoint.ValueString()
+	if !list.Path.IsNull() && !list.Path.IsUnknown() {
+		var err error
+		endpoint, err = expandPathTemplate(list.Path.ValueString(), r.pathTemplateValues(data))
+		if err != nil {
+			return nil, statusDecision{}, "", err
+		}
+	}
+
+	keyField, key := listMatchKey(data)
+	if key == "" {
+		return nil, statusDecision{}, endpoint, fmt.Errorf("cannot find the object in %s: the resource has no ID or name to match", endpoint)
+	}
+	if !list.KeyField.IsNull() && !list.KeyField.IsUnknown() {
+		keyField = list.KeyField.ValueString()
+	}
+
+	options := r.buildRequestOptions(ctx, data, r.resolveMethodForOperation(data, "read"), "")
+	options.Endpoint = endpoint
+	// The elements are needed to find the object
+	options.StreamResponse = false
+	options.SpoolPath = ""
+
+	policy, err := r.statusPolicy(ctx, data, "read")
+	if err != nil {
+		return nil, statusDecision{}, endpoint, err
+	}
+
+	var result *client.Response
+	var resultDecision statusDecision
+	err = fetchPages(ctx, r.client, options, policy, list.Pagination, list.ItemsPath.ValueString(), func(page *client.Response, decision statusDecision) (bool, error) {
+		if decision.outcome != statusSucceeded {
+			result = page
+			resultDecision = decision
+			return true, nil
+		}
+
+		element, found, err := findListElement(page.Body, list.ItemsPath.ValueString(), keyField, key)
+		if err != nil {
+			return false, fmt.Errorf("reading collection %s: %w", endpoint, err)
+		}
+		if !found {
+			return false, nil
+		}
+
+		body, err := json.Marshal(element)
+		if err != nil {
+			return false, err
+		}
+		digest := sha256.Sum256(body)
+		result = &client.Response{
+			StatusCode: page.StatusCode,
+			Body:       body,
+			Headers:    page.Headers,
+			Request:    page.Request,
+			URL:        page.URL,
+			Timings:    page.Timings,
+			Size:       int64(len(body)),
+			SHA256:     hex.EncodeToString(digest[:]),
+		}
+		resultDecision = decision
+		return true, nil
+	})
+	if err != nil {
+		return nil, statusDecision{}, endpoint, err
+	}
+
+	if result == nil {
+		tflog.Debug(ctx, "object not found in collection", map[string]interface{}{
+			"endpoint":  endpoint,
+			"key_field": keyField,
+			"key":       key,
+		})
+		notFound := &client.Response{StatusCode: http.StatusNotFound}
+		return notFound, policy.evaluate(notFound.StatusCode), endpoint, nil
+	}
+	return result, resultDecision, endpoint, nil
+}
+
+// listMatchKey returns which stored value read_from_list looks for, "id" or
+// "name", and that value
+func listMatchKey(data *RestResourceModel) (string, string) {
+	id := ""
+	if !data.Id.IsNull() && !data.Id.IsUnknown() {
+		id = data.Id.ValueString()
+	}
+	name := ""
+	if !data.Name.IsNull() && !data.Name.IsUnknown() {
+		name = data.Name.ValueString()
+	}
+
+	if data.ReadFromList.MatchOn.ValueString() == "name" || id == "" {
+		return "name", name
+	}
+	return "id", id
+}
+
+// findListElement returns the element of the array at itemsPath in body whose
+// keyField equals key
+func findListElement(body []byte, itemsPath, keyField, key string) (interface{}, bool, error) {
+	elements, err := pageItems(body, itemsPath)
+	if err != nil {
+		return nil, false, err
+	}
+
+	for _, element := range elements {
+		value, found, err := lookupJSONPath(element, keyField)
+		if err != nil {
+			return nil, false, fmt.Errorf("invalid key_field: %w", err)
+		}
+		if !found {
+			continue
+		}
+		if text, ok := jsonScalarString(value); ok && text == key {
+			return element, true, nil
+		}
+	}
+	return nil, false, nil
+}
@@ -0,0 +1,209 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-rest/internal/client"
+)
+
+func TestFindListElement(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		itemsPath string
+		keyField  string
+		key       string
+		expected  string
+		found     bool
+		errMsg    string
+	}{
+		{
+			name:     "root array",
+			body:     `[{"id": "a"}, {"id": "b", "size": 2}]`,
+			keyField: "id",
+			key:      "b",
+			expected: `{"id":"b","size":2}`,
+			found:    true,
+		},
+		{
+			name:      "nested array and numeric key",
+			body:      `{"data": {"items": [{"id": 41}, {"id": 12345678901234567}]}}`,
+			itemsPath: "$.data.items",
+			keyField:  "id",
+			key:       "12345678901234567",
+			expected:  `{"id":12345678901234567}`,
+			found:     true,
+		},
+		{
+			name:      "nested key field",
+			body:      `{"items": [{"metadata": {"name": "web"}}]}`,
+			itemsPath: "items",
+			keyField:  "metadata.name",
+			key:       "web",
+			expected:  `{"metadata":{"name":"web"}}`,
+			found:     true,
+		},
+		{
+			name:      "not on this page",
+			body:      `{"items": [{"id": "a"}]}`,
+			itemsPath: "items",
+			keyField:  "id",
+			key:       "b",
+		},
+		{
+			name:     "object without items_path",
+			body:     `{"items": []}`,
+			keyField: "id",
+			key:      "a",
+			errMsg:   "no array found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			element, found, err := findListElement([]byte(tt.body), tt.itemsPath, tt.keyField, tt.key)
+			if tt.errMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+					t.Errorf("Expected error containing %q, got %v", tt.errMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if found != tt.found {
+				t.Fatalf("Expected found=%v, got %v", tt.found, found)
+			}
+			if found {
+				if body, _ := json.Marshal(element); string(body) != tt.expected {
+					t.Errorf("Expected %s, got %s", tt.expected, body)
+				}
+			}
+		})
+	}
+}
+
+func TestRestResource_ReadFromList(t *testing.T) {
+	tests := []struct {
+		name     string
+		id       types.String
+		matchOn  types.String
+		status   int
+		expected int
+		outcome  statusOutcome
+		body     string
+	}{
+		{
+			name:     "found on the second page",
+			id:       types.StringValue("7"),
+			matchOn:  types.StringNull(),
+			status:   http.StatusOK,
+			expected: http.StatusOK,
+			body:     `{"id":"7","name":"web-7"}`,
+		},
+		{
+			name:     "match on name",
+			id:       types.StringValue("unused"),
+			matchOn:  types.StringValue("name"),
+			status:   http.StatusOK,
+			expected: http.StatusOK,
+			body:     `{"id":"2","name":"web"}`,
+		},
+		{
+			name:     "name while the ID is unknown",
+			id:       types.StringNull(),
+			matchOn:  types.StringNull(),
+			status:   http.StatusOK,
+			expected: http.StatusOK,
+			body:     `{"id":"2","name":"web"}`,
+		},
+		{
+			name:     "missing from the collection",
+			id:       types.StringValue("99"),
+			matchOn:  types.StringNull(),
+			status:   http.StatusOK,
+			expected: http.StatusNotFound,
+			outcome:  statusGone,
+		},
+		{
+			name:     "collection error",
+			id:       types.StringValue("7"),
+			matchOn:  types.StringNull(),
+			status:   http.StatusForbidden,
+			expected: http.StatusForbidden,
+			outcome:  statusFailed,
+			body:     `{"error":"denied"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/projects/p1/items" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				if tt.status != http.StatusOK {
+					w.WriteHeader(tt.status)
+					_, _ = w.Write([]byte(`{"error":"denied"}`))
+					return
+				}
+				if r.URL.Query().Get("cursor") == "" {
+					_, _ = w.Write([]byte(`{"data": [{"id": "1", "name": "api"}, {"id": "2", "name": "web"}], "next": "/projects/p1/items?cursor=c2"}`))
+					return
+				}
+				_, _ = w.Write([]byte(`{"data": [{"id": "7", "name": "web-7"}], "next": null}`))
+			}))
+			defer server.Close()
+
+			restClient, err := client.NewRestClient(client.Config{BaseURL: server.URL})
+			if err != nil {
+				t.Fatalf("Failed to create client: %s", err)
+			}
+			r := &RestResource{client: restClient}
+			data := &RestResourceModel{
+				Id:       tt.id,
+				Endpoint: types.StringValue("/items"),
+				Name:     types.StringValue("web"),
+				ResponseData: types.MapValueMust(types.StringType, map[string]attr.Value{
+					"project_id": types.StringValue("p1"),
+				}),
+				ReadFromList: &ReadFromListModel{
+					Path:      types.StringValue("/projects/{project_id}/items"),
+					ItemsPath: types.StringValue("data"),
+					KeyField:  types.StringNull(),
+					MatchOn:   tt.matchOn,
+					Pagination: &PaginationModel{
+						Type:     types.StringValue(paginationNextURL),
+						NextPath: types.StringValue("next"),
+						MaxPages: types.Int64Null(),
+					},
+				},
+			}
+
+			response, decision, endpoint, err := r.readObject(context.Background(), data)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if endpoint != "/projects/p1/items" {
+				t.Errorf("Expected the collection path, got %s", endpoint)
+			}
+			if response.StatusCode != tt.expected || string(response.Body) != tt.body {
+				t.Errorf("Expected %d %s, got %d %s", tt.expected, tt.body, response.StatusCode, response.Body)
+			}
+			if decision.outcome != tt.outcome {
+				t.Errorf("Expected outcome %d, got %d", tt.outcome, decision.outcome)
+			}
+			if tt.expected == http.StatusOK && response.SHA256 == "" {
+				t.Errorf("Expected the element digest to be set")
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"terraform-provider-rest/internal/client"
+)
+
+// requestSettings holds the request settings shared by rest_resource,
+// rest_action and rest_data. Settings a model does not have are left null.
+type requestSettings struct {
+	Headers            map[string]types.String
+	SensitiveHeaders   map[string]types.String
+	QueryParams        map[string]types.String
+	RepeatedHeaders    map[string][]types.String
+	RepeatedQuery      map[string][]types.String
+	RawQuery           types.String
+	Timeout            types.Int64
+	RetryAttempts      types.Int64
+	Insecure           types.Bool
+	MaxResponseBytes   types.Int64
+	StreamResponse     types.Bool
+	ResponseSpoolPath  types.String
+	RequestCompression types.String
+}
+
+// newRequestOptions creates client.RequestOptions from request settings
+func newRequestOptions(method, endpoint, body string, settings requestSettings) client.RequestOptions {
+	options := client.RequestOptions{
+		Method:   method,
+		Endpoint: endpoint,
+	}
+
+	// Add body if provided
+	if body != "" {
+		options.Body = []byte(body)
+	}
+
+	// Add custom headers
+	if settings.Headers != nil {
+		customHeaders := make(map[string]string)
+		for key, value := range settings.Headers {
+			customHeaders[key] = value.ValueString()
+		}
+		options.Headers = customHeaders
+	}
+
+	// Add write-only headers over the stored ones
+	if len(settings.SensitiveHeaders) > 0 {
+		if options.Headers == nil {
+			options.Headers = make(map[string]string, len(settings.SensitiveHeaders))
+		}
+		for key, value := range settings.SensitiveHeaders {
+			options.Headers[key] = value.ValueString()
+		}
+	}
+
+	// Add query parameters
+	if settings.QueryParams != nil {
+		queryParams := make(map[string]string)
+		for key, value := range settings.QueryParams {
+			queryParams[key] = value.ValueString()
+		}
+		options.QueryParams = queryParams
+	}
+
+	// Add multi-value headers and query parameters
+	options.HeaderValues = stringListMapValues(settings.RepeatedHeaders)
+	options.QueryValues = stringListMapValues(settings.RepeatedQuery)
+
+	// Add raw query string
+	if !settings.RawQuery.IsNull() {
+		options.RawQuery = settings.RawQuery.ValueString()
+	}
+
+	// Set timeout if provided
+	if !settings.Timeout.IsNull() {
+		options.Timeout = time.Duration(settings.Timeout.ValueInt64()) * time.Second
+	}
+
+	// Set retry attempts if provided
+	if !settings.RetryAttempts.IsNull() {
+		options.Retries = int(settings.RetryAttempts.ValueInt64())
+	}
+
+	// Skip certificate verification if requested
+	options.Insecure = settings.Insecure.ValueBool()
+
+	// Configure response size handling
+	if !settings.MaxResponseBytes.IsNull() {
+		options.MaxResponseBytes = settings.MaxResponseBytes.ValueInt64()
+	}
+	if !settings.StreamResponse.IsNull() && settings.StreamResponse.ValueBool() {
+		options.StreamResponse = true
+		if !settings.ResponseSpoolPath.IsNull() {
+			options.SpoolPath = settings.ResponseSpoolPath.ValueString()
+		}
+	}
+
+	// Set request body compression if provided
+	if !settings.RequestCompression.IsNull() {
+		options.Compression = settings.RequestCompression.ValueString()
+	}
+
+	return options
+}
+
+// responseHeadersValue converts response headers into the response_headers
+// map, keeping the first value of each header
+func responseHeadersValue(ctx context.Context, headers map[string][]string) types.Map {
+	responseHeaders := make(map[string]attr.Value)
+	for key, values := range headers {
+		if len(values) > 0 {
+			responseHeaders[key] = types.StringValue(values[0])
+		}
+	}
+	headersMap, diags := types.MapValue(types.StringType, responseHeaders)
+	if diags.HasError() {
+		tflog.Warn(ctx, "failed to create response headers map", map[string]interface{}{
+			"errors": diags.Errors(),
+		})
+		return types.MapNull(types.StringType)
+	}
+	return headersMap
+}
@@ -0,0 +1,40 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestNewRequestOptions(t *testing.T) {
+	options := newRequestOptions("POST", "/items", `{"a":1}`, requestSettings{
+		Headers:          map[string]types.String{"X-Team": types.StringValue("web"), "Authorization": types.StringValue("stored")},
+		SensitiveHeaders: map[string]types.String{"Authorization": types.StringValue("secret")},
+		QueryParams:      map[string]types.String{"dry_run": types.StringValue("true")},
+		RepeatedQuery:    map[string][]types.String{"tag": {types.StringValue("a"), types.StringValue("b")}},
+		RawQuery:         types.StringValue("x=%2F"),
+		Timeout:          types.Int64Value(7),
+		RetryAttempts:    types.Int64Value(2),
+		Insecure:         types.BoolValue(true),
+	})
+
+	if options.Method != "POST" || options.Endpoint != "/items" || string(options.Body) != `{"a":1}` {
+		t.Errorf("Unexpected request %s %s %s", options.Method, options.Endpoint, options.Body)
+	}
+	if options.Headers["X-Team"] != "web" || options.Headers["Authorization"] != "secret" {
+		t.Errorf("Expected write-only headers over stored ones, got %v", options.Headers)
+	}
+	if options.QueryParams["dry_run"] != "true" || len(options.QueryValues["tag"]) != 2 || options.RawQuery != "x=%2F" {
+		t.Errorf("Unexpected query %v, %v, %q", options.QueryParams, options.QueryValues, options.RawQuery)
+	}
+	if options.Timeout != 7*time.Second || options.Retries != 2 || !options.Insecure {
+		t.Errorf("Unexpected timeout %s, retries %d or insecure %v", options.Timeout, options.Retries, options.Insecure)
+	}
+
+	// Settings a model does not have stay at the client defaults
+	options = newRequestOptions("GET", "/items", "", requestSettings{})
+	if options.Body != nil || options.Headers != nil || options.QueryParams != nil || options.Timeout != 0 || options.Insecure || options.StreamResponse {
+		t.Errorf("Expected empty settings to leave defaults, got %+v", options)
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// responseObjectDescription is the description of the response_object attribute
+const responseObjectDescription = "The JSON response body as a Terraform value with its structure and types kept: objects, arrays, numbers and booleans can be used directly, e.g. `response_object.items[0].name`. Null when the body is not JSON."
+
+// responseObjectValue decodes a JSON body into the response_object value.
+// Bodies that are empty or not JSON give a null value.
+func responseObjectValue(ctx context.Context, body []byte) types.Dynamic {
+	if len(body) == 0 {
+		return types.DynamicNull()
+	}
+	document, err := decodeJSONDocument(body)
+	if err != nil {
+		return types.DynamicNull()
+	}
+
+	value, err := jsonAttrValue(ctx, document)
+	if err != nil {
+		tflog.Warn(ctx, "failed to convert response to response_object", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return types.DynamicNull()
+	}
+	return types.DynamicValue(value)
+}
+
+// jsonAttrValue converts a value decoded by decodeJSONDocument into a Terraform
+// value: objects become objects, arrays become tuples so their elements may
+// differ in type, and numbers keep their full precision. A JSON null has no
+// type of its own and becomes a null string.
+func jsonAttrValue(ctx context.Context, value interface{}) (attr.Value, error) {
+	switch v := value.(type) {
+	case nil:
+		return types.StringNull(), nil
+	case string:
+		return types.StringValue(v), nil
+	case bool:
+		return types.BoolValue(v), nil
+	case json.Number:
+		number, _, err := big.ParseFloat(v.String(), 10, 512, big.ToNearestEven)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %s: %w", v, err)
+		}
+		return types.NumberValue(number), nil
+	case []interface{}:
+		elementTypes := make([]attr.Type, 0, len(v))
+		elements := make([]attr.Value, 0, len(v))
+		for _, item := range v {
+			element, err := jsonAttrValue(ctx, item)
+			if err != nil {
+				return nil, err
+			}
+			elementTypes = append(elementTypes, element.Type(ctx))
+			elements = append(elements, element)
+		}
+		tuple, diags := types.TupleValue(elementTypes, elements)
+		if diags.HasError() {
+			return nil, fmt.Errorf("converting array: %v", diags.Errors())
+		}
+		return tuple, nil
+	case map[string]interface{}:
+		attributeTypes := make(map[string]attr.Type, len(v))
+		attributes := make(map[string]attr.Value, len(v))
+		for key, item := range v {
+			attribute, err := jsonAttrValue(ctx, item)
+			if err != nil {
+				return nil, err
+			}
+			attributeTypes[key] = attribute.Type(ctx)
+			attributes[key] = attribute
+		}
+		object, diags := types.ObjectValue(attributeTypes, attributes)
+		if diags.HasError() {
+			return nil, fmt.Errorf("converting object: %v", diags.Errors())
+		}
+		return object, nil
+	default:
+		return nil, fmt.Errorf("unsupported JSON value of type %T", value)
+	}
+}
@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestResponseObjectValue(t *testing.T) {
+	number := func(text string) types.Number {
+		value, _, _ := big.ParseFloat(text, 10, 512, big.ToNearestEven)
+		return types.NumberValue(value)
+	}
+
+	tests := []struct {
+		name     string
+		body     string
+		expected attr.Value
+	}{
+		{
+			name:     "empty body",
+			body:     ``,
+			expected: nil,
+		},
+		{
+			name:     "not JSON",
+			body:     `<html></html>`,
+			expected: nil,
+		},
+		{
+			name: "nested object",
+			body: `{"name": "web", "ratio": 1.5, "enabled": true, "labels": {"team": "a"}, "owner": null}`,
+			expected: types.ObjectValueMust(
+				map[string]attr.Type{
+					"name":    types.StringType,
+					"ratio":   types.NumberType,
+					"enabled": types.BoolType,
+					"labels":  types.ObjectType{AttrTypes: map[string]attr.Type{"team": types.StringType}},
+					"owner":   types.StringType,
+				},
+				map[string]attr.Value{
+					"name":    types.StringValue("web"),
+					"ratio":   number("1.5"),
+					"enabled": types.BoolValue(true),
+					"labels":  types.ObjectValueMust(map[string]attr.Type{"team": types.StringType}, map[string]attr.Value{"team": types.StringValue("a")}),
+					"owner":   types.StringNull(),
+				},
+			),
+		},
+		{
+			name: "array root with mixed elements",
+			body: `[12345678901234567, "two", [], {}]`,
+			expected: types.TupleValueMust(
+				[]attr.Type{types.NumberType, types.StringType, types.TupleType{ElemTypes: []attr.Type{}}, types.ObjectType{AttrTypes: map[string]attr.Type{}}},
+				[]attr.Value{
+					number("12345678901234567"),
+					types.StringValue("two"),
+					types.TupleValueMust([]attr.Type{}, []attr.Value{}),
+					types.ObjectValueMust(map[string]attr.Type{}, map[string]attr.Value{}),
+				},
+			),
+		},
+		{
+			name:     "scalar root",
+			body:     `"ok"`,
+			expected: types.StringValue("ok"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value := responseObjectValue(context.Background(), []byte(tt.body))
+			if tt.expected == nil {
+				if !value.IsNull() {
+					t.Errorf("Expected null, got %s", value)
+				}
+				return
+			}
+			if !value.UnderlyingValue().Equal(tt.expected) {
+				t.Errorf("Expected %s, got %s", tt.expected, value.UnderlyingValue())
+			}
+		})
+	}
+}
+
+func TestResponseDataValue_Numbers(t *testing.T) {
+	data := responseDataValue(context.Background(), []byte(`{"ratio": 1.5, "count": 42, "id": 12345678901234567, "nested": {"price": 9.99}}`))
+
+	expected := map[string]string{
+		"ratio":  "1.5",
+		"count":  "42",
+		"id":     "12345678901234567",
+		"nested": `{"price":9.99}`,
+	}
+	elements := data.Elements()
+	for key, value := range expected {
+		if !elements[key].Equal(types.StringValue(value)) {
+			t.Errorf("Expected response_data[%s] = %s, got %v", key, value, elements[key])
+		}
+	}
+}
@@ -0,0 +1,331 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"terraform-provider-rest/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &RestActionResource{}
+var _ resource.ResourceWithModifyPlan = &RestActionResource{}
+
+func NewRestActionResource() resource.Resource {
+	return &RestActionResource{}
+}
+
+// RestActionResource sends a one-shot request on create and whenever its
+// triggers change. It never reads the endpoint back.
+type RestActionResource struct {
+	client *client.RestClient
+}
+
+// RestActionModel describes the action resource data model.
+type RestActionModel struct {
+	Id            types.String            `tfsdk:"id"`
+	Endpoint      types.String            `tfsdk:"endpoint"`
+	Method        types.String            `tfsdk:"method"`
+	Headers       map[string]types.String `tfsdk:"headers"`
+	QueryParams   map[string]types.String `tfsdk:"query_params"`
+	Body          types.String            `tfsdk:"body"`
+	Triggers      types.Map               `tfsdk:"triggers"`
+	StatusPolicy  *StatusPolicyModel      `tfsdk:"status_policy"`
+	OnDestroy     *ActionRequestModel     `tfsdk:"on_destroy"`
+	Timeout       types.Int64             `tfsdk:"timeout"`
+	RetryAttempts types.Int64             `tfsdk:"retry_attempts"`
+	// Result of the most recent run
+	StatusCode   types.Int64  `tfsdk:"status_code"`
+	Response     types.String `tfsdk:"response"`
+	ResponseData types.Map    `tfsdk:"response_data"`
+	Timings      types.Object `tfsdk:"timings"`
+	TriggeredAt  types.String `tfsdk:"triggered_at"`
+}
+
+// ActionRequestModel describes the optional request sent when an action is
+// destroyed.
+type ActionRequestModel struct {
+	Endpoint types.String `tfsdk:"endpoint"`
+	Method   types.String `tfsdk:"method"`
+	Body     types.String `tfsdk:"body"`
+}
+
+func (a *RestActionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_action"
+}
+
+func (a *RestActionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	methods := []validator.String{
+		stringvalidator.OneOf("GET", "POST", "PUT", "PATCH", "DELETE"),
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Sends a one-shot API request, such as purging a cache or rotating a key. The request is sent on create and again whenever `triggers` change; refresh and destroy send nothing unless `on_destroy` is set.",
+
+		Attributes: map[string]schema.Attribute{
+			"endpoint": schema.StringAttribute{
+				MarkdownDescription: "The API endpoint to send the request to. Resolved against the provider `api_url` like `rest_resource` endpoints.",
+				Required:            true,
+			},
+			"method": schema.StringAttribute{
+				MarkdownDescription: "The HTTP method of the request. Default: POST.",
+				Optional:            true,
+				Validators:          methods,
+			},
+			"headers": schema.MapAttribute{
+				MarkdownDescription: "Custom headers to include in the request.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"query_params": schema.MapAttribute{
+				MarkdownDescription: "Query parameters to include in the request.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"body": schema.StringAttribute{
+				MarkdownDescription: "The request body.",
+				Optional:            true,
+			},
+			"triggers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary values that send the request again when they change, e.g. a key version or a content hash. Changes to other arguments are saved without sending a request.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"status_policy": operationStatusPolicyAttribute("action", "`gone` is not used."),
+			"on_destroy": schema.SingleNestedAttribute{
+				MarkdownDescription: "A request to send when the action is destroyed. A `404` or `410` response counts as success.",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"endpoint": schema.StringAttribute{
+						MarkdownDescription: "The API endpoint to send the request to.",
+						Required:            true,
+					},
+					"method": schema.StringAttribute{
+						MarkdownDescription: "The HTTP method of the request. Default: POST.",
+						Optional:            true,
+						Validators:          methods,
+					},
+					"body": schema.StringAttribute{
+						MarkdownDescription: "The request body.",
+						Optional:            true,
+					},
+				},
+			},
+			"timeout": schema.Int64Attribute{
+				MarkdownDescription: "Request timeout in seconds.",
+				Optional:            true,
+			},
+			"retry_attempts": schema.Int64Attribute{
+				MarkdownDescription: "Number of retry attempts for transient failures.",
+				Optional:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The identifier of the action.",
+				Computed:            true,
+			},
+			"status_code": schema.Int64Attribute{
+				MarkdownDescription: "The HTTP status code of the most recent run.",
+				Computed:            true,
+			},
+			"response": schema.StringAttribute{
+				MarkdownDescription: "The response body of the most recent run.",
+				Computed:            true,
+			},
+			"response_data": schema.MapAttribute{
+				MarkdownDescription: "The top-level fields of the most recent JSON response.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"timings": resourceTimingsAttribute(),
+			"triggered_at": schema.StringAttribute{
+				MarkdownDescription: "When the request was last sent (RFC 3339).",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (a *RestActionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	a.client = providerData.Client
+}
+
+// ModifyPlan keeps the results of the last run when an update will not send the
+// request, so only trigger changes show them as changing
+func (a *RestActionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state RestActionModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() || triggersChanged(&plan, &state) {
+		return
+	}
+
+	copyActionResults(&plan, &state)
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+func (a *RestActionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RestActionModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	a.run(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read keeps the recorded result; an action has nothing to read back
+func (a *RestActionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+}
+
+func (a *RestActionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state RestActionModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if triggersChanged(&data, &state) {
+		a.run(ctx, &data, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	} else {
+		copyActionResults(&data, &state)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (a *RestActionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data RestActionModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.OnDestroy == nil {
+		return
+	}
+
+	method := "POST"
+	if !data.OnDestroy.Method.IsNull() {
+		method = data.OnDestroy.Method.ValueString()
+	}
+	options := a.requestOptions(ctx, &data, data.OnDestroy.Endpoint, method, data.OnDestroy.Body)
+
+	response, decision, err := doWithStatusPolicy(ctx, a.client, options, defaultStatusPolicy("delete"))
+	if err != nil {
+		addRequestError(&resp.Diagnostics, err, method, options.Endpoint)
+		return
+	}
+	if decision.outcome == statusFailed {
+		resp.Diagnostics.AddError("API Error", statusError(response))
+		return
+	}
+
+	tflog.Trace(ctx, "sent REST action on_destroy request", map[string]interface{}{
+		"method":      method,
+		"endpoint":    options.Endpoint,
+		"status_code": response.StatusCode,
+	})
+}
+
+// run sends the action request, checks it against the status policy and
+// records the result in the model
+func (a *RestActionResource) run(ctx context.Context, data *RestActionModel, diags *diag.Diagnostics) {
+	method := "POST"
+	if !data.Method.IsNull() {
+		method = data.Method.ValueString()
+	}
+	options := a.requestOptions(ctx, data, data.Endpoint, method, data.Body)
+
+	policy, err := newStatusPolicy("create", data.StatusPolicy)
+	if err != nil {
+		diags.AddError("Invalid Status Policy", err.Error())
+		return
+	}
+
+	response, decision, err := doWithStatusPolicy(ctx, a.client, options, policy)
+	if err != nil {
+		addRequestError(diags, err, method, options.Endpoint)
+		return
+	}
+	if err := checkFailedStatus(ctx, response, decision); err != nil {
+		diags.AddError("API Error", err.Error())
+		return
+	}
+
+	data.Id = types.StringValue(fmt.Sprintf("%s_%s", method, data.Endpoint.ValueString()))
+	data.StatusCode = types.Int64Value(int64(response.StatusCode))
+	data.Response = types.StringValue(string(response.Body))
+	data.ResponseData = responseDataValue(ctx, response.Body)
+	data.TriggeredAt = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+
+	timings, timingDiags := timingsObjectValue(response.Timings)
+	diags.Append(timingDiags...)
+	data.Timings = timings
+
+	tflog.Trace(ctx, "sent REST action", map[string]interface{}{
+		"method":      method,
+		"endpoint":    options.Endpoint,
+		"status_code": response.StatusCode,
+	})
+}
+
+// requestOptions builds an action request with the same header, query, timeout
+// and retry handling as rest_resource
+func (a *RestActionResource) requestOptions(ctx context.Context, data *RestActionModel, endpoint types.String, method string, body types.String) client.RequestOptions {
+	return newRequestOptions(method, endpoint.ValueString(), body.ValueString(), requestSettings{
+		Headers:       data.Headers,
+		QueryParams:   data.QueryParams,
+		Timeout:       data.Timeout,
+		RetryAttempts: data.RetryAttempts,
+	})
+}
+
+// triggersChanged reports whether an update has to send the request again
+func triggersChanged(plan, state *RestActionModel) bool {
+	return plan.Triggers.IsUnknown() || !plan.Triggers.Equal(state.Triggers)
+}
+
+// copyActionResults carries the results of the last run from state into plan
+func copyActionResults(plan, state *RestActionModel) {
+	plan.Id = state.Id
+	plan.StatusCode = state.StatusCode
+	plan.Response = state.Response
+	plan.ResponseData = state.ResponseData
+	plan.Timings = state.Timings
+	plan.TriggeredAt = state.TriggeredAt
+}
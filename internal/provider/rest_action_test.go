@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-rest/internal/client"
+)
+
+func TestRestActionResource_Run(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       types.String
+		status       int
+		policy       *StatusPolicyModel
+		expectMethod string
+		errMsg       string
+	}{
+		{
+			name:         "default POST",
+			method:       types.StringNull(),
+			status:       http.StatusAccepted,
+			expectMethod: "POST",
+		},
+		{
+			name:         "configured method",
+			method:       types.StringValue("PUT"),
+			status:       http.StatusOK,
+			expectMethod: "PUT",
+		},
+		{
+			name:         "failed status",
+			method:       types.StringNull(),
+			status:       http.StatusConflict,
+			expectMethod: "POST",
+			errMsg:       "409",
+		},
+		{
+			name:         "status policy accepts conflict",
+			method:       types.StringNull(),
+			status:       http.StatusConflict,
+			policy:       &StatusPolicyModel{Success: statusValues("2xx", "409")},
+			expectMethod: "POST",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var method, body, header, query string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				method = r.Method
+				header = r.Header.Get("X-Reason")
+				query = r.URL.Query().Get("scope")
+				requestBody, _ := io.ReadAll(r.Body)
+				body = string(requestBody)
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(`{"purged": 12, "status": "done"}`))
+			}))
+			defer server.Close()
+
+			restClient, err := client.NewRestClient(client.Config{BaseURL: server.URL})
+			if err != nil {
+				t.Fatalf("Failed to create client: %s", err)
+			}
+			a := &RestActionResource{client: restClient}
+			data := &RestActionModel{
+				Endpoint:     types.StringValue("/cache/purge"),
+				Method:       tt.method,
+				Headers:      map[string]types.String{"X-Reason": types.StringValue("deploy")},
+				QueryParams:  map[string]types.String{"scope": types.StringValue("all")},
+				Body:         types.StringValue(`{"paths":["/*"]}`),
+				StatusPolicy: tt.policy,
+			}
+			var diags diag.Diagnostics
+
+			a.run(context.Background(), data, &diags)
+
+			if method != tt.expectMethod || header != "deploy" || query != "all" || body != `{"paths":["/*"]}` {
+				t.Errorf("Unexpected request %s with header %q, query %q and body %s", method, header, query, body)
+			}
+			if tt.errMsg != "" {
+				if !diags.HasError() || !strings.Contains(diags.Errors()[0].Detail(), tt.errMsg) {
+					t.Errorf("Expected error containing %q, got %v", tt.errMsg, diags)
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("Unexpected errors: %v", diags)
+			}
+
+			if data.StatusCode.ValueInt64() != int64(tt.status) {
+				t.Errorf("Expected status_code %d, got %s", tt.status, data.StatusCode)
+			}
+			if !data.ResponseData.Elements()["purged"].Equal(types.StringValue("12")) {
+				t.Errorf("Expected response_data to be parsed, got %v", data.ResponseData)
+			}
+			if data.Id.IsNull() || data.TriggeredAt.IsNull() {
+				t.Errorf("Expected id and triggered_at to be set")
+			}
+		})
+	}
+}
+
+func TestTriggersChanged(t *testing.T) {
+	triggers := func(values map[string]string) types.Map {
+		elements := make(map[string]attr.Value, len(values))
+		for key, value := range values {
+			elements[key] = types.StringValue(value)
+		}
+		return types.MapValueMust(types.StringType, elements)
+	}
+
+	tests := []struct {
+		name     string
+		plan     types.Map
+		state    types.Map
+		expected bool
+	}{
+		{name: "no triggers", plan: types.MapNull(types.StringType), state: types.MapNull(types.StringType)},
+		{name: "unchanged", plan: triggers(map[string]string{"version": "1"}), state: triggers(map[string]string{"version": "1"})},
+		{name: "changed value", plan: triggers(map[string]string{"version": "2"}), state: triggers(map[string]string{"version": "1"}), expected: true},
+		{name: "added", plan: triggers(map[string]string{"version": "1"}), state: types.MapNull(types.StringType), expected: true},
+		{name: "unknown", plan: types.MapUnknown(types.StringType), state: triggers(map[string]string{"version": "1"}), expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if changed := triggersChanged(&RestActionModel{Triggers: tt.plan}, &RestActionModel{Triggers: tt.state}); changed != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, changed)
+			}
+		})
+	}
+}
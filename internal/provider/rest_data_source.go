@@ -5,10 +5,11 @@ package provider
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -31,17 +32,29 @@ type RestDataSource struct {
 
 // RestDataSourceModel describes the data source data model.
 type RestDataSourceModel struct {
-	Id            types.String            `tfsdk:"id"`
-	Endpoint      types.String            `tfsdk:"endpoint"`
-	Method        types.String            `tfsdk:"method"`
-	Headers       map[string]types.String `tfsdk:"headers"`
-	Body          types.String            `tfsdk:"body"`
-	Response      types.String            `tfsdk:"response"`
-	StatusCode    types.Int64             `tfsdk:"status_code"`
-	ParsedData    map[string]types.String `tfsdk:"parsed_data"`
-	Timeout       types.Int64             `tfsdk:"timeout"`
-	Insecure      types.Bool              `tfsdk:"insecure"`
-	RetryAttempts types.Int64             `tfsdk:"retry_attempts"`
+	Id              types.String              `tfsdk:"id"`
+	Endpoint        types.String              `tfsdk:"endpoint"`
+	Method          types.String              `tfsdk:"method"`
+	Headers         map[string]types.String   `tfsdk:"headers"`
+	QueryParams     map[string]types.String   `tfsdk:"query_params"`
+	RepeatedHeaders map[string][]types.String `tfsdk:"repeated_headers"`
+	RepeatedQuery   map[string][]types.String `tfsdk:"repeated_query_params"`
+	RawQuery        types.String              `tfsdk:"raw_query"`
+	Body            types.String              `tfsdk:"body"`
+	Response        types.String              `tfsdk:"response"`
+	StatusCode      types.Int64               `tfsdk:"status_code"`
+	ResponseHeaders types.Map                 `tfsdk:"response_headers"`
+	ResponseData    types.Map                 `tfsdk:"response_data"`
+	ResponseObject  types.Dynamic             `tfsdk:"response_object"`
+	ParsedData      map[string]types.String   `tfsdk:"parsed_data"`
+	Timings         types.Object              `tfsdk:"timings"`
+	Timeout         types.Int64               `tfsdk:"timeout"`
+	Insecure        types.Bool                `tfsdk:"insecure"`
+	RetryAttempts   types.Int64               `tfsdk:"retry_attempts"`
+	StatusPolicy    *StatusPolicyModel        `tfsdk:"status_policy"`
+	Pagination      *PaginationModel          `tfsdk:"pagination"`
+	ItemsPath       types.String              `tfsdk:"items_path"`
+	Items           types.List                `tfsdk:"items"`
 }
 
 func (d *RestDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -54,7 +67,7 @@ func (d *RestDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 
 		Attributes: map[string]schema.Attribute{
 			"endpoint": schema.StringAttribute{
-				MarkdownDescription: "The API endpoint to send the request to (relative to the base URL).",
+				MarkdownDescription: "The API endpoint to send the request to. Resolved as a URI reference against the base URL, so relative paths such as `../v2/items` and absolute URLs on an `allowed_hosts` host are supported.",
 				Required:            true,
 			},
 			"method": schema.StringAttribute{
@@ -70,6 +83,25 @@ func (d *RestDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 				Optional:            true,
 				ElementType:         types.StringType,
 			},
+			"query_params": schema.MapAttribute{
+				MarkdownDescription: "Query parameters to include in the request.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"repeated_headers": schema.MapAttribute{
+				MarkdownDescription: "Headers with multiple values to include in the request. Each value is sent as a separate header line, in order.",
+				Optional:            true,
+				ElementType:         types.ListType{ElemType: types.StringType},
+			},
+			"repeated_query_params": schema.MapAttribute{
+				MarkdownDescription: "Query parameters with multiple values to include in the request, sent as `?key=a&key=b`.",
+				Optional:            true,
+				ElementType:         types.ListType{ElemType: types.StringType},
+			},
+			"raw_query": schema.StringAttribute{
+				MarkdownDescription: "A pre-encoded query string appended to the URL verbatim.",
+				Optional:            true,
+			},
 			"body": schema.StringAttribute{
 				MarkdownDescription: "The request body, used with POST or PUT methods.",
 				Optional:            true,
@@ -86,23 +118,50 @@ func (d *RestDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 				MarkdownDescription: "The HTTP status code from the request.",
 				Computed:            true,
 			},
+			"response_headers": schema.MapAttribute{
+				MarkdownDescription: "The response headers from the request.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"response_data": schema.MapAttribute{
+				MarkdownDescription: "The parsed response data as key-value pairs (for JSON responses), in the same format as the `rest_resource` `response_data`.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"response_object": schema.DynamicAttribute{
+				MarkdownDescription: responseObjectDescription + " With `pagination`, the body of the first page.",
+				Computed:            true,
+			},
 			"parsed_data": schema.MapAttribute{
 				MarkdownDescription: "Parsed JSON attributes from the response body.",
+				DeprecationMessage:  "Use response_data instead.",
 				Computed:            true,
 				ElementType:         types.StringType,
 			},
+			"timings": dataSourceTimingsAttribute(),
 			"timeout": schema.Int64Attribute{
 				MarkdownDescription: "Timeout for the request in seconds.",
 				Optional:            true,
 			},
 			"insecure": schema.BoolAttribute{
-				MarkdownDescription: "Disable SSL certificate verification.",
+				MarkdownDescription: "Disable SSL certificate verification for this request.",
 				Optional:            true,
 			},
 			"retry_attempts": schema.Int64Attribute{
 				MarkdownDescription: "Number of retry attempts for the request.",
 				Optional:            true,
 			},
+			"status_policy": dataSourceStatusPolicyAttribute("data source", "Without it, any `2xx` status is a success and other statuses fail the read. `gone` is not used."),
+			"pagination":    dataSourcePaginationAttribute(),
+			"items_path": schema.StringAttribute{
+				MarkdownDescription: "JSON path or pointer to the array of elements in each page, e.g. `data` or `$.result.items`, collected into `items`. Default: the response body itself is the array when `pagination` is set.",
+				Optional:            true,
+			},
+			"items": schema.ListAttribute{
+				MarkdownDescription: "The elements of all pages, each as JSON text; use `jsondecode` to access their fields. Only set with `pagination` or `items_path`.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
 		},
 	}
 }
@@ -141,51 +200,55 @@ func (d *RestDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 		method = data.Method.ValueString()
 	}
 
-	// Prepare request options
-	requestOptions := client.RequestOptions{
-		Method:   method,
-		Endpoint: data.Endpoint.ValueString(),
-	}
-
-	// Add request body if provided
-	if !data.Body.IsNull() {
-		requestOptions.Body = []byte(data.Body.ValueString())
-	}
-
-	// Add custom headers if provided
-	if data.Headers != nil {
-		customHeaders := make(map[string]string)
-		for key, value := range data.Headers {
-			customHeaders[key] = value.ValueString()
-		}
-		requestOptions.Headers = customHeaders
-	}
-
-	// Set timeout if provided
-	if !data.Timeout.IsNull() {
-		requestOptions.Timeout = time.Duration(data.Timeout.ValueInt64()) * time.Second
-	}
+	requestOptions := newRequestOptions(method, data.Endpoint.ValueString(), data.Body.ValueString(), requestSettings{
+		Headers:         data.Headers,
+		QueryParams:     data.QueryParams,
+		RepeatedHeaders: data.RepeatedHeaders,
+		RepeatedQuery:   data.RepeatedQuery,
+		RawQuery:        data.RawQuery,
+		Timeout:         data.Timeout,
+		RetryAttempts:   data.RetryAttempts,
+		Insecure:        data.Insecure,
+	})
 
-	// Set retry attempts if provided
-	if !data.RetryAttempts.IsNull() {
-		requestOptions.Retries = int(data.RetryAttempts.ValueInt64())
+	policy, err := newStatusPolicy("create", data.StatusPolicy)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Status Policy", err.Error())
+		return
 	}
 
-	// Make the request using the REST client
-	response, err := d.client.Do(ctx, requestOptions)
+	// Make the request using the REST client, following the pages of the
+	// collection when pagination is configured
+	response, pages, err := d.readPages(ctx, &data, requestOptions, policy)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"HTTP Request Failed",
-			fmt.Sprintf("Unable to send %s request to %s: %s", method, data.Endpoint.ValueString(), err),
-		)
+		var failed *pageStatusError
+		switch {
+		case errors.As(err, &failed):
+			resp.Diagnostics.AddError("API Error", failed.Error())
+		case response == nil:
+			addRequestError(&resp.Diagnostics, err, method, data.Endpoint.ValueString())
+		default:
+			resp.Diagnostics.AddError(
+				"Pagination Failed",
+				fmt.Sprintf("Unable to read the pages of %s: %s", data.Endpoint.ValueString(), err),
+			)
+		}
 		return
 	}
 
 	// Set the status code and response body
 	data.StatusCode = types.Int64Value(int64(response.StatusCode))
 	data.Response = types.StringValue(string(response.Body))
+	data.ResponseHeaders = responseHeadersValue(ctx, response.Headers)
+	data.ResponseData = responseDataValue(ctx, response.Body)
+	data.ResponseObject = responseObjectValue(ctx, response.Body)
 	data.Id = types.StringValue(fmt.Sprintf("%s_%s", method, data.Endpoint.ValueString()))
 
+	// Set request timings
+	timings, diags := timingsObjectValue(response.Timings)
+	resp.Diagnostics.Append(diags...)
+	data.Timings = timings
+
 	// Set the computed method value
 	data.Method = types.StringValue(method)
 
@@ -216,8 +279,75 @@ func (d *RestDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 		"method":      method,
 		"endpoint":    data.Endpoint.ValueString(),
 		"status_code": response.StatusCode,
+		"pages":       pages,
 	})
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// pageStatusError reports a page whose status failed the status policy
+type pageStatusError struct {
+	page     int
+	response *client.Response
+}
+
+func (e *pageStatusError) Error() string {
+	if e.page > 1 {
+		return fmt.Sprintf("Page %d: %s", e.page, statusError(e.response))
+	}
+	return statusError(e.response)
+}
+
+// readPages sends the request and follows the pages described by pagination,
+// checking each against policy and collecting the elements at items_path into
+// items. It returns the first page, which describes the request, and the
+// number of pages read; the first page is nil when the request could not be
+// sent.
+func (d *RestDataSource) readPages(ctx context.Context, data *RestDataSourceModel, options client.RequestOptions, policy *statusPolicy) (*client.Response, int, error) {
+	collect := data.Pagination != nil || !data.ItemsPath.IsNull()
+	itemsPath := data.ItemsPath.ValueString()
+
+	var first *client.Response
+	items := []attr.Value{}
+	pages := 0
+	err := fetchPages(ctx, d.client, options, policy, data.Pagination, itemsPath, func(page *client.Response, decision statusDecision) (bool, error) {
+		pages++
+		if pages == 1 {
+			first = page
+		}
+		if decision.outcome != statusSucceeded {
+			// Only the first page may continue past a failure; a failed later
+			// page would silently truncate items
+			if pages > 1 || checkFailedStatus(ctx, page, decision) != nil {
+				return true, &pageStatusError{page: pages, response: page}
+			}
+			return true, nil
+		}
+		if !collect {
+			return false, nil
+		}
+
+		elements, err := pageItems(page.Body, itemsPath)
+		if err != nil {
+			return false, fmt.Errorf("page %d: %w", pages, err)
+		}
+		for _, element := range elements {
+			text, err := json.Marshal(element)
+			if err != nil {
+				return false, err
+			}
+			items = append(items, types.StringValue(string(text)))
+		}
+		return false, nil
+	})
+	if err != nil {
+		return first, pages, err
+	}
+
+	data.Items = types.ListNull(types.StringType)
+	if collect {
+		data.Items = types.ListValueMust(types.StringType, items)
+	}
+	return first, pages, nil
+}
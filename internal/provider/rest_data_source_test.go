@@ -0,0 +1,189 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-rest/internal/client"
+)
+
+func TestRestDataSource_ReadPages(t *testing.T) {
+	tests := []struct {
+		name       string
+		pagination *PaginationModel
+		itemsPath  types.String
+		failPage   bool
+		pages      int
+		items      []string
+		errMsg     string
+	}{
+		{
+			name:      "single request",
+			itemsPath: types.StringNull(),
+			pages:     1,
+		},
+		{
+			name:      "items of one page",
+			itemsPath: types.StringValue("users"),
+			pages:     1,
+			items:     []string{`{"id":1,"name":"ada"}`, `{"id":2,"name":"bob"}`},
+		},
+		{
+			name:       "items of all pages",
+			pagination: &PaginationModel{Type: types.StringValue(paginationNextURL), NextPath: types.StringValue("next")},
+			itemsPath:  types.StringValue("users"),
+			pages:      2,
+			items:      []string{`{"id":1,"name":"ada"}`, `{"id":2,"name":"bob"}`, `{"id":3,"name":"cy"}`},
+		},
+		{
+			name:       "failed later page",
+			pagination: &PaginationModel{Type: types.StringValue(paginationNextURL), NextPath: types.StringValue("next")},
+			itemsPath:  types.StringValue("users"),
+			failPage:   true,
+			errMsg:     "Page 2: Received non-success response code: 403",
+		},
+		{
+			name:       "items path missing",
+			pagination: &PaginationModel{},
+			itemsPath:  types.StringNull(),
+			errMsg:     "no array found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Query().Get("page") != "2" {
+					_, _ = w.Write([]byte(`{"users": [{"id": 1, "name": "ada"}, {"id": 2, "name": "bob"}], "next": "/users?page=2"}`))
+					return
+				}
+				if tt.failPage {
+					w.WriteHeader(http.StatusForbidden)
+					return
+				}
+				_, _ = w.Write([]byte(`{"users": [{"id": 3, "name": "cy"}], "next": null}`))
+			}))
+			defer server.Close()
+
+			restClient, err := client.NewRestClient(client.Config{BaseURL: server.URL})
+			if err != nil {
+				t.Fatalf("Failed to create client: %s", err)
+			}
+			d := &RestDataSource{client: restClient}
+			data := &RestDataSourceModel{
+				Endpoint:   types.StringValue("/users"),
+				Pagination: tt.pagination,
+				ItemsPath:  tt.itemsPath,
+			}
+
+			first, pages, err := d.readPages(context.Background(), data, client.RequestOptions{Method: "GET", Endpoint: "/users"}, defaultStatusPolicy("create"))
+			if tt.errMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+					t.Errorf("Expected error containing %q, got %v", tt.errMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			if first == nil || first.StatusCode != http.StatusOK || pages != tt.pages {
+				t.Errorf("Expected the first page of %d, got %v after %d pages", tt.pages, first, pages)
+			}
+			if tt.items == nil {
+				if !data.Items.IsNull() {
+					t.Errorf("Expected null items, got %s", data.Items)
+				}
+				return
+			}
+			var items []string
+			for _, element := range data.Items.Elements() {
+				items = append(items, element.(types.String).ValueString())
+			}
+			if strings.Join(items, "|") != strings.Join(tt.items, "|") {
+				t.Errorf("Expected items %v, got %v", tt.items, items)
+			}
+		})
+	}
+}
+
+func TestRestDataSource_StatusPolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []int
+		policy   *StatusPolicyModel
+		expected int
+		errMsg   string
+	}{
+		{
+			name:     "success",
+			statuses: []int{http.StatusOK},
+			expected: http.StatusOK,
+		},
+		{
+			name:     "failed status fails the read",
+			statuses: []int{http.StatusNotFound},
+			errMsg:   "Received non-success response code: 404",
+		},
+		{
+			name:     "continue on failure",
+			statuses: []int{http.StatusNotFound},
+			policy:   &StatusPolicyModel{OnFailure: types.StringValue("continue")},
+			expected: http.StatusNotFound,
+		},
+		{
+			name:     "retry on status",
+			statuses: []int{http.StatusLocked, http.StatusOK},
+			policy:   &StatusPolicyModel{Retry: statusValues("423"), RetryInterval: types.Int64Value(0)},
+			expected: http.StatusOK,
+		},
+		{
+			name:     "fail on a success status",
+			statuses: []int{http.StatusNoContent},
+			policy:   &StatusPolicyModel{Fail: statusValues("204")},
+			errMsg:   "204",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.statuses[min(requests, len(tt.statuses)-1)])
+				requests++
+			}))
+			defer server.Close()
+
+			restClient, err := client.NewRestClient(client.Config{BaseURL: server.URL})
+			if err != nil {
+				t.Fatalf("Failed to create client: %s", err)
+			}
+			policy, err := newStatusPolicy("create", tt.policy)
+			if err != nil {
+				t.Fatalf("Invalid policy: %s", err)
+			}
+			d := &RestDataSource{client: restClient}
+			data := &RestDataSourceModel{Endpoint: types.StringValue("/status")}
+
+			first, _, err := d.readPages(context.Background(), data, client.RequestOptions{Method: "GET", Endpoint: "/status"}, policy)
+			if tt.errMsg != "" {
+				var failed *pageStatusError
+				if !errors.As(err, &failed) || !strings.Contains(err.Error(), tt.errMsg) {
+					t.Errorf("Expected status error containing %q, got %v", tt.errMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if first.StatusCode != tt.expected {
+				t.Errorf("Expected status %d, got %d", tt.expected, first.StatusCode)
+			}
+		})
+	}
+}
@@ -5,17 +5,27 @@ package provider
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"terraform-provider-rest/internal/client"
 )
@@ -23,6 +33,8 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &RestResource{}
 var _ resource.ResourceWithImportState = &RestResource{}
+var _ resource.ResourceWithUpgradeState = &RestResource{}
+var _ resource.ResourceWithValidateConfig = &RestResource{}
 
 func NewRestResource() resource.Resource {
 	return &RestResource{}
@@ -35,37 +47,111 @@ type RestResource struct {
 
 // RestResourceModel describes the resource data model.
 type RestResourceModel struct {
-	Id              types.String            `tfsdk:"id"`
-	Endpoint        types.String            `tfsdk:"endpoint"`
-	Name            types.String            `tfsdk:"name"`
-	Method          types.String            `tfsdk:"method"`
-	CreateMethod    types.String            `tfsdk:"create_method"`
-	ReadMethod      types.String            `tfsdk:"read_method"`
-	UpdateMethod    types.String            `tfsdk:"update_method"`
-	DeleteMethod    types.String            `tfsdk:"delete_method"`
-	Headers         map[string]types.String `tfsdk:"headers"`
-	QueryParams     map[string]types.String `tfsdk:"query_params"`
-	Body            types.String            `tfsdk:"body"`
-	UpdateBody      types.String            `tfsdk:"update_body"`
-	DestroyBody     types.String            `tfsdk:"destroy_body"`
-	Response        types.String            `tfsdk:"response"`
-	StatusCode      types.Int64             `tfsdk:"status_code"`
-	ResponseHeaders types.Map               `tfsdk:"response_headers"`
-	ResponseData    types.Map               `tfsdk:"response_data"`
-	CreatedAt       types.String            `tfsdk:"created_at"`
-	LastUpdated     types.String            `tfsdk:"last_updated"`
-	Timeout         types.Int64             `tfsdk:"timeout"`
-	Insecure        types.Bool              `tfsdk:"insecure"`
-	RetryAttempts   types.Int64             `tfsdk:"retry_attempts"`
+	Id              types.String              `tfsdk:"id"`
+	Endpoint        types.String              `tfsdk:"endpoint"`
+	Name            types.String              `tfsdk:"name"`
+	Method          types.String              `tfsdk:"method"`
+	CreateMethod    types.String              `tfsdk:"create_method"`
+	ReadMethod      types.String              `tfsdk:"read_method"`
+	UpdateMethod    types.String              `tfsdk:"update_method"`
+	DeleteMethod    types.String              `tfsdk:"delete_method"`
+	Headers         map[string]types.String   `tfsdk:"headers"`
+	QueryParams     map[string]types.String   `tfsdk:"query_params"`
+	RepeatedHeaders map[string][]types.String `tfsdk:"repeated_headers"`
+	RepeatedQuery   map[string][]types.String `tfsdk:"repeated_query_params"`
+	RawQuery        types.String              `tfsdk:"raw_query"`
+	Body            JSONBody                  `tfsdk:"body"`
+	UpdateBody      JSONBody                  `tfsdk:"update_body"`
+	DestroyBody     JSONBody                  `tfsdk:"destroy_body"`
+	UpdateStrategy  types.String              `tfsdk:"update_strategy"`
+	// Handling of objects that already exist on create
+	OnCreateConflict        types.String `tfsdk:"on_create_conflict"`
+	CheckExistsBeforeCreate types.Bool   `tfsdk:"check_exists_before_create"`
+	// Write-only request data, only available from configuration
+	BodyWO           JSONBody                `tfsdk:"body_wo"`
+	BodyWOVersion    types.Int64             `tfsdk:"body_wo_version"`
+	SensitiveHeaders map[string]types.String `tfsdk:"sensitive_headers"`
+	// Response fields removed before they are stored
+	RedactResponsePaths types.List `tfsdk:"redact_response_paths"`
+	SensitiveResponse   types.Bool `tfsdk:"sensitive_response"`
+	// Sensitive copies of response and response_data used by sensitive_response
+	SensitiveResponseBody types.String  `tfsdk:"sensitive_response_body"`
+	SensitiveResponseData types.Map     `tfsdk:"sensitive_response_data"`
+	Response              types.String  `tfsdk:"response"`
+	ResponseSHA256        types.String  `tfsdk:"response_sha256"`
+	ResponseSize          types.Int64   `tfsdk:"response_size"`
+	StatusCode            types.Int64   `tfsdk:"status_code"`
+	ResponseHeaders       types.Map     `tfsdk:"response_headers"`
+	ResponseData          types.Map     `tfsdk:"response_data"`
+	ResponseObject        types.Dynamic `tfsdk:"response_object"`
+	Timings               types.Object  `tfsdk:"timings"`
+	CreatedAt             types.String  `tfsdk:"created_at"`
+	LastUpdated           types.String  `tfsdk:"last_updated"`
+	Timeout               types.Int64   `tfsdk:"timeout"`
+	Insecure              types.Bool    `tfsdk:"insecure"`
+	RetryAttempts         types.Int64   `tfsdk:"retry_attempts"`
+	// Response size handling
+	MaxResponseBytes   types.Int64  `tfsdk:"max_response_bytes"`
+	StreamResponse     types.Bool   `tfsdk:"stream_response"`
+	ResponseSpoolPath  types.String `tfsdk:"response_spool_path"`
+	RequestCompression types.String `tfsdk:"request_compression"`
 	// Conditional operations
-	ExpectedStatus types.List   `tfsdk:"expected_status"`
-	OnSuccess      types.String `tfsdk:"on_success"`
-	OnFailure      types.String `tfsdk:"on_failure"`
-	FailOnStatus   types.List   `tfsdk:"fail_on_status"`
-	RetryOnStatus  types.List   `tfsdk:"retry_on_status"`
+	ExpectedStatus types.List           `tfsdk:"expected_status"`
+	OnSuccess      types.String         `tfsdk:"on_success"`
+	OnFailure      types.String         `tfsdk:"on_failure"`
+	FailOnStatus   types.List           `tfsdk:"fail_on_status"`
+	RetryOnStatus  types.List           `tfsdk:"retry_on_status"`
+	StatusPolicy   *StatusPoliciesModel `tfsdk:"status_policy"`
 	// Drift detection configuration
-	IgnoreFields   types.List `tfsdk:"ignore_fields"`
-	DriftDetection types.Bool `tfsdk:"drift_detection"`
+	IgnoreFields   types.List   `tfsdk:"ignore_fields"`
+	DriftDetection types.Bool   `tfsdk:"drift_detection"`
+	DriftAction    types.String `tfsdk:"drift_action"`
+	// DriftIgnoreMissingKeys skips configured keys the API does not return
+	DriftIgnoreMissingKeys types.Bool `tfsdk:"drift_ignore_missing_keys"`
+	// Drift comparison rules and report
+	ArrayKeyFields             map[string]types.String `tfsdk:"array_key_fields"`
+	DisableDefaultIgnoreFields types.Bool              `tfsdk:"disable_default_ignore_fields"`
+	DriftReport                types.List              `tfsdk:"drift_report"`
+	// ID extraction
+	IdAttribute  types.String       `tfsdk:"id_attribute"`
+	IdFromHeader *IdFromHeaderModel `tfsdk:"id_from_header"`
+	// Per-operation path templates
+	ObjectPath types.String `tfsdk:"object_path"`
+	ReadPath   types.String `tfsdk:"read_path"`
+	UpdatePath types.String `tfsdk:"update_path"`
+	DeletePath types.String `tfsdk:"delete_path"`
+	// Location header tracking
+	UseLocationHeader types.Bool   `tfsdk:"use_location_header"`
+	SelfLink          types.String `tfsdk:"self_link"`
+	// Long-running operations
+	Poll *PollModel `tfsdk:"poll"`
+	// State convergence
+	WaitForReady  *WaitForReadyModel  `tfsdk:"wait_for_ready"`
+	WaitForDelete *WaitForDeleteModel `tfsdk:"wait_for_delete"`
+	// Soft deletes
+	GoneWhen    *GoneWhenModel `tfsdk:"gone_when"`
+	DeleteMode  types.String   `tfsdk:"delete_mode"`
+	PurgePath   types.String   `tfsdk:"purge_path"`
+	PurgeMethod types.String   `tfsdk:"purge_method"`
+	// List-only APIs
+	ReadFromList *ReadFromListModel `tfsdk:"read_from_list"`
+	// Lifecycle hooks
+	PreCreate     *HookModel `tfsdk:"pre_create"`
+	PostCreate    *HookModel `tfsdk:"post_create"`
+	PreUpdate     *HookModel `tfsdk:"pre_update"`
+	PostUpdate    *HookModel `tfsdk:"post_update"`
+	PreDelete     *HookModel `tfsdk:"pre_delete"`
+	PostDelete    *HookModel `tfsdk:"post_delete"`
+	HookResponses types.Map  `tfsdk:"hook_responses"`
+	// Optimistic concurrency
+	OptimisticLocking *OptimisticLockingModel `tfsdk:"optimistic_locking"`
+	ConcurrencyToken  types.String            `tfsdk:"concurrency_token"`
+}
+
+// IdFromHeaderModel describes how to extract the resource ID from a response header.
+type IdFromHeaderModel struct {
+	Name    types.String `tfsdk:"name"`
+	Pattern types.String `tfsdk:"pattern"`
 }
 
 func (r *RestResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -75,10 +161,12 @@ func (r *RestResource) Metadata(ctx context.Context, req resource.MetadataReques
 func (r *RestResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "REST resource to create, read, update, and delete items via API with full HTTP method support.",
+		// Version 1 switched the body attributes to the JSON body type
+		Version: 1,
 
 		Attributes: map[string]schema.Attribute{
 			"endpoint": schema.StringAttribute{
-				MarkdownDescription: "The API endpoint to send the request to (relative to the base URL).",
+				MarkdownDescription: "The API endpoint to send the request to. Resolved as a URI reference against the base URL, so relative paths such as `../v2/items` and absolute URLs on an `allowed_hosts` host are supported.",
 				Required:            true,
 			},
 			"id": schema.StringAttribute{
@@ -86,8 +174,8 @@ func (r *RestResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Computed:            true,
 			},
 			"name": schema.StringAttribute{
-				MarkdownDescription: "The name of the item to be used for identification during read, update and delete operations.",
-				Required:            true,
+				MarkdownDescription: "The name of the item to be used for identification during read, update and delete operations. When omitted, those operations target `endpoint/{id}` unless a path template is set.",
+				Optional:            true,
 			},
 			"method": schema.StringAttribute{
 				MarkdownDescription: "The HTTP method to use for create operations (POST, PUT, PATCH). Default: POST. DEPRECATED: Use create_method, read_method, update_method, delete_method instead.",
@@ -139,22 +227,98 @@ func (r *RestResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Optional:            true,
 				ElementType:         types.StringType,
 			},
+			"repeated_headers": schema.MapAttribute{
+				MarkdownDescription: "Headers with multiple values to include in requests. Each value is sent as a separate header line, in order, and replaces any value set in `headers` for the same name.",
+				Optional:            true,
+				ElementType:         types.ListType{ElemType: types.StringType},
+			},
+			"repeated_query_params": schema.MapAttribute{
+				MarkdownDescription: "Query parameters with multiple values to include in requests, sent as `?key=a&key=b`.",
+				Optional:            true,
+				ElementType:         types.ListType{ElemType: types.StringType},
+			},
+			"raw_query": schema.StringAttribute{
+				MarkdownDescription: "A pre-encoded query string appended to the URL verbatim, after any `query_params`. Use this for order-sensitive or already-escaped values such as `filter=a%2Cb`.",
+				Optional:            true,
+			},
 			"body": schema.StringAttribute{
-				MarkdownDescription: "The body for create requests. This can be a JSON object or any payload that the API expects.",
+				MarkdownDescription: "The body for create requests. This can be a JSON object or any payload that the API expects. JSON bodies are compared by content, so formatting and key order changes are not drift, and bodies starting with `{` or `[` must be valid JSON.",
 				Optional:            true,
+				CustomType:          JSONBodyType{},
 			},
 			"update_body": schema.StringAttribute{
-				MarkdownDescription: "The body for update requests. If not specified, uses the same body as create.",
+				MarkdownDescription: "The body for update requests. If not specified, uses the same body as create. JSON bodies are compared by content like `body`.",
 				Optional:            true,
+				CustomType:          JSONBodyType{},
 			},
 			"destroy_body": schema.StringAttribute{
-				MarkdownDescription: "The body for delete requests. This can be a JSON object or any payload that the API expects.",
+				MarkdownDescription: "The body for delete requests. This can be a JSON object or any payload that the API expects. JSON bodies are compared by content like `body`.",
+				Optional:            true,
+				CustomType:          JSONBodyType{},
+			},
+			"update_strategy": schema.StringAttribute{
+				MarkdownDescription: "How updates send the body: `put_full` sends the whole `update_body` or `body`; `merge_patch` sends an RFC 7396 merge patch and `json_patch` an RFC 6902 operation list, computed from the body in state to the planned body. Patch strategies default `update_method` to PATCH and set the matching `Content-Type` unless `headers` sets one. Default: `put_full`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(updateStrategyPutFull, updateStrategyMergePatch, updateStrategyJSONPatch),
+				},
+			},
+			"on_create_conflict": schema.StringAttribute{
+				MarkdownDescription: "What to do when create finds the object already exists, either from a `409 Conflict` response or from `check_exists_before_create`: `fail`, `adopt` (read the existing object into state) or `update` (read it, then update it to match the configuration). The object is looked up at its read path, which must not depend on the ID, e.g. through `name` or `object_path`. Default: `fail`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(createConflictFail, createConflictAdopt, createConflictUpdate),
+				},
+			},
+			"check_exists_before_create": schema.BoolAttribute{
+				MarkdownDescription: "Read the object before creating it, for APIs that do not return `409 Conflict` for duplicates. An existing object is handled according to `on_create_conflict`. Default: false.",
+				Optional:            true,
+			},
+			"body_wo": schema.StringAttribute{
+				MarkdownDescription: "Write-only body for create requests, and for update requests without `update_body`. It is sent but never stored in plan or state, so use it for bodies containing secrets. Changes are only applied when `body_wo_version` changes. Requires Terraform 1.11 or later.",
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+				CustomType:          JSONBodyType{},
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("body")),
+				},
+			},
+			"body_wo_version": schema.Int64Attribute{
+				MarkdownDescription: "Version of `body_wo`. Terraform cannot see changes to write-only values, so change this number to send an update with the new `body_wo`.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("body_wo")),
+				},
+			},
+			"sensitive_headers": schema.MapAttribute{
+				MarkdownDescription: "Write-only request headers, such as secret API keys, merged over `headers`. They are never stored in plan or state, and because Terraform only provides write-only values from configuration they are sent with create and update requests (including their polling and waiting) but not with refresh or delete requests. Requires Terraform 1.11 or later.",
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+				ElementType:         types.StringType,
+			},
+			"redact_response_paths": schema.ListAttribute{
+				MarkdownDescription: "Fields to remove from JSON responses before they are stored in `response` and `response_data`, as dotted paths or JSON pointers with optional `*` and `[*]` wildcards, e.g. `credentials.secret` or `keys[*].private_key`. Redacted responses are re-encoded compactly.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"sensitive_response": schema.BoolAttribute{
+				MarkdownDescription: "Store the response in the sensitive `sensitive_response_body` and `sensitive_response_data` attributes instead of `response` and `response_data`, which are left null. Terraform cannot mark an attribute sensitive conditionally, so the response moves to attributes that always are. Default: false.",
 				Optional:            true,
 			},
 			"response": schema.StringAttribute{
 				MarkdownDescription: "The response from the most recent API request.",
 				Computed:            true,
 			},
+			"response_sha256": schema.StringAttribute{
+				MarkdownDescription: "The hex-encoded SHA-256 digest of the most recent response body.",
+				Computed:            true,
+			},
+			"response_size": schema.Int64Attribute{
+				MarkdownDescription: "The size in bytes of the most recent response body.",
+				Computed:            true,
+			},
 			"status_code": schema.Int64Attribute{
 				MarkdownDescription: "The HTTP status code from the most recent API request.",
 				Computed:            true,
@@ -169,6 +333,22 @@ func (r *RestResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Computed:            true,
 				ElementType:         types.StringType,
 			},
+			"response_object": schema.DynamicAttribute{
+				MarkdownDescription: responseObjectDescription + " Null when `sensitive_response` or `stream_response` is enabled.",
+				Computed:            true,
+			},
+			"sensitive_response_body": schema.StringAttribute{
+				MarkdownDescription: "The response from the most recent API request when `sensitive_response` is enabled. Marked sensitive so it is hidden in plan output.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"sensitive_response_data": schema.MapAttribute{
+				MarkdownDescription: "The parsed response data when `sensitive_response` is enabled. Marked sensitive so it is hidden in plan output.",
+				Computed:            true,
+				Sensitive:           true,
+				ElementType:         types.StringType,
+			},
+			"timings": resourceTimingsAttribute(),
 			"created_at": schema.StringAttribute{
 				MarkdownDescription: "Timestamp when the resource was created.",
 				Computed:            true,
@@ -189,6 +369,28 @@ func (r *RestResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				MarkdownDescription: "Number of retry attempts for failed requests.",
 				Optional:            true,
 			},
+			"max_response_bytes": schema.Int64Attribute{
+				MarkdownDescription: "Maximum size in bytes of a buffered response body. Larger responses fail with a diagnostic. Overrides the provider setting.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"stream_response": schema.BoolAttribute{
+				MarkdownDescription: "Stream the response body instead of storing it in state. The body is hashed (and optionally written to `response_spool_path`), and only `response_sha256` and `response_size` are recorded; `response`, `response_data` and drift detection are skipped. Default: false.",
+				Optional:            true,
+			},
+			"response_spool_path": schema.StringAttribute{
+				MarkdownDescription: "File path to write the response body to when `stream_response` is enabled.",
+				Optional:            true,
+			},
+			"request_compression": schema.StringAttribute{
+				MarkdownDescription: "Compress request bodies with `gzip` or `zstd` and set `Content-Encoding` automatically. Use `none` to disable compression configured on the provider.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("gzip", "zstd", "none"),
+				},
+			},
 			"expected_status": schema.ListAttribute{
 				MarkdownDescription: "List of expected HTTP status codes for successful operations. If specified, only these status codes will be considered successful.",
 				Optional:            true,
@@ -218,20 +420,163 @@ func (r *RestResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Optional:            true,
 				ElementType:         types.Int64Type,
 			},
+			"status_policy": statusPolicyAttribute(),
 			"ignore_fields": schema.ListAttribute{
-				MarkdownDescription: "List of field names in the API response to ignore during drift detection. Useful for server-side metadata fields like 'created_at', 'updated_at', 'etag', etc.",
+				MarkdownDescription: "List of fields in the API response to ignore during drift detection. Plain names like `updated_at` are ignored at any depth; dotted paths and JSON pointers like `metadata.version`, `/metadata/version`, `items[*].etag` or `labels.*` only match that location. Useful for server-side metadata fields like 'created_at', 'updated_at', 'etag', etc.",
 				Optional:            true,
 				ElementType:         types.StringType,
 			},
+			"array_key_fields": schema.MapAttribute{
+				MarkdownDescription: "Compare arrays by a key field instead of by position, so reordering is not drift. Keys are paths to arrays (wildcards allowed), values are the field identifying each element, e.g. `{ \"members\" = \"id\", \"rules[*].targets\" = \"name\" }`.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"disable_default_ignore_fields": schema.BoolAttribute{
+				MarkdownDescription: "Stop ignoring the built-in list of common server-managed fields (`id`, `created_at`, `version`, `etag`, ...) during drift detection. Only `ignore_fields` is applied. Default: false.",
+				Optional:            true,
+			},
+			"drift_report": schema.ListNestedAttribute{
+				MarkdownDescription: "Fields of `body` that differ from the live object, as found by the most recent refresh. `expected` and `actual` are JSON encoded; `actual` is null for values missing from the object and `expected` is null for array elements that are not configured.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"path": schema.StringAttribute{
+							MarkdownDescription: "Location of the value, e.g. `labels.team` or `members[id=alice].role`.",
+							Computed:            true,
+						},
+						"expected": schema.StringAttribute{
+							MarkdownDescription: "The configured value.",
+							Computed:            true,
+						},
+						"actual": schema.StringAttribute{
+							MarkdownDescription: "The live value.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"drift_action": schema.StringAttribute{
+				MarkdownDescription: "What to do when the live object no longer matches a JSON `body`: `reconcile` writes the live values of the configured keys into the `body` in state so `terraform plan` shows the drift and plans an update, `warn` only reports it, and `error` fails the refresh. Default: `reconcile`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("warn", "reconcile", "error"),
+				},
+			},
+			"drift_ignore_missing_keys": schema.BoolAttribute{
+				MarkdownDescription: "Do not treat configured `body` keys that the live object does not return as drift, for APIs that never echo some fields back. Such keys keep their configured value in state. To skip only some keys, list them in `ignore_fields` instead. Default: false.",
+				Optional:            true,
+			},
 			"drift_detection": schema.BoolAttribute{
 				MarkdownDescription: "Enable drift detection for response body changes. When enabled, compares planned vs actual response to detect configuration drift. Default: true.",
 				Optional:            true,
 				Computed:            true,
 			},
+			"id_attribute": schema.StringAttribute{
+				MarkdownDescription: "Location of the resource ID in the JSON response body, as a dotted path (`data.uuid`, `$.items[0].id`) or a JSON pointer (`/data/uuid`). String, number and boolean values are accepted. When set, a response without this value is an error. Default: the top-level `id` field, falling back to a generated ID.",
+				Optional:            true,
+				Validators: []validator.String{
+					validJSONPath(),
+				},
+			},
+			"id_from_header": schema.SingleNestedAttribute{
+				MarkdownDescription: "Extract the resource ID from a response header, such as `Location`. Takes precedence over `id_attribute`. A response without a matching header is an error.",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						MarkdownDescription: "The response header to read, e.g. `Location`.",
+						Required:            true,
+					},
+					"pattern": schema.StringAttribute{
+						MarkdownDescription: "A regular expression applied to the header value. The first capture group (or the whole match if there are no groups) becomes the ID, e.g. `/items/([^/?]+)$`. Default: the whole header value.",
+						Optional:            true,
+						Validators: []validator.String{
+							validRegex(),
+						},
+					},
+				},
+			},
+			"object_path": schema.StringAttribute{
+				MarkdownDescription: "Path template for read, update and delete operations, e.g. `/orgs/{org}/items/{id}`. Placeholders are `{id}`, `{name}`, `{endpoint}` and any top-level response field; values are escaped as a single path segment, or inserted verbatim with `{+placeholder}`. Default: `endpoint/name`, or `endpoint/{id}` when `name` is not set.",
+				Optional:            true,
+			},
+			"read_path": schema.StringAttribute{
+				MarkdownDescription: "Path template for read operations. Overrides `object_path`.",
+				Optional:            true,
+			},
+			"update_path": schema.StringAttribute{
+				MarkdownDescription: "Path template for update operations, e.g. `/items/{id}:update`. Overrides `object_path`.",
+				Optional:            true,
+			},
+			"delete_path": schema.StringAttribute{
+				MarkdownDescription: "Path template for delete operations. Overrides `object_path`.",
+				Optional:            true,
+			},
+			"use_location_header": schema.BoolAttribute{
+				MarkdownDescription: "Store the `Location` header of the create response in `self_link` and send read, update and delete requests there instead of `endpoint/name`. Relative and absolute values are accepted; absolute URLs must be on the `api_url` host or in `allowed_hosts`. Operation-specific path templates still take precedence. Default: false.",
+				Optional:            true,
+			},
+			"self_link": schema.StringAttribute{
+				MarkdownDescription: "The absolute URL of the object, resolved from the `Location` header of the create response when `use_location_header` is enabled.",
+				Computed:            true,
+			},
+			"poll":            pollAttribute(),
+			"wait_for_ready":  waitForReadyAttribute(),
+			"wait_for_delete": waitForDeleteAttribute(),
+			"gone_when":       goneWhenAttribute(),
+			"delete_mode": schema.StringAttribute{
+				MarkdownDescription: "How the object is deleted: `hard` (default) sends the delete request only; `soft_then_purge` follows it with a purge request for APIs that keep deleted objects in a trash or archive.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(deleteModeHard, deleteModeSoftThenPurge),
+				},
+			},
+			"purge_path": schema.StringAttribute{
+				MarkdownDescription: "Path template for the purge request of `delete_mode = \"soft_then_purge\"`, e.g. `/trash/{id}` or `/items/{id}?permanent=true`. Default: the delete path.",
+				Optional:            true,
+			},
+			"purge_method": schema.StringAttribute{
+				MarkdownDescription: "HTTP method for the purge request. Default: `DELETE`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("DELETE", "POST", "PUT", "PATCH"),
+				},
+			},
+			"read_from_list":     readFromListAttribute(),
+			"optimistic_locking": optimisticLockingAttribute(),
+			"pre_create":         hookAttribute("Request sent before the create request, e.g. to reserve a name."),
+			"post_create":        hookAttribute("Request sent after the object is created and any `poll` finished, before `wait_for_ready`, e.g. `/items/{id}/activate`. If it fails, the object is saved to state and tainted."),
+			"pre_update":         hookAttribute("Request sent before the update request."),
+			"post_update":        hookAttribute("Request sent after the object is updated and any `poll` finished, before `wait_for_ready`, e.g. `/items/{id}/publish`."),
+			"pre_delete":         hookAttribute("Request sent before the delete request, e.g. `/items/{id}/disable`. If it fails, the object is not deleted."),
+			"post_delete":        hookAttribute("Request sent after the object is deleted, purged and any `wait_for_delete` finished."),
+			"hook_responses":     hookResponsesAttribute(),
+			"concurrency_token": schema.StringAttribute{
+				MarkdownDescription: "The version of the object recorded by `optimistic_locking` when it was last read, created or updated.",
+				Computed:            true,
+			},
 		},
 	}
 }
 
+// ValidateConfig checks combinations of settings that attribute validators
+// cannot express
+func (r *RestResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var locking types.Object
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("optimistic_locking"), &locking)...)
+	if resp.Diagnostics.HasError() || locking.IsNull() || locking.IsUnknown() {
+		return
+	}
+
+	var model OptimisticLockingModel
+	resp.Diagnostics.Append(locking.As(ctx, &model, basetypes.ObjectAsOptions{})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if err := validateOptimisticLocking(&model); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("optimistic_locking"), "Invalid Optimistic Locking", err.Error())
+	}
+}
+
 func (r *RestResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -250,183 +595,142 @@ func (r *RestResource) Configure(ctx context.Context, req resource.ConfigureRequ
 	r.client = providerData.Client
 }
 
-// checkStatusCode evaluates if a status code should be considered successful based on conditional rules
-func (r *RestResource) checkStatusCode(ctx context.Context, statusCode int, data *RestResourceModel) (bool, string) {
-	// Check if this status should be treated as a failure
-	if !data.FailOnStatus.IsNull() {
-		failStatuses := make([]int64, 0, len(data.FailOnStatus.Elements()))
-		diags := data.FailOnStatus.ElementsAs(ctx, &failStatuses, false)
-		if !diags.HasError() {
-			for _, failStatus := range failStatuses {
-				if int(failStatus) == statusCode {
-					action := "fail"
-					if !data.OnFailure.IsNull() {
-						action = data.OnFailure.ValueString()
-					}
-					return false, action
-				}
-			}
-		}
-	}
-
-	// Check expected status codes if specified
-	if !data.ExpectedStatus.IsNull() {
-		expectedStatuses := make([]int64, 0, len(data.ExpectedStatus.Elements()))
-		diags := data.ExpectedStatus.ElementsAs(ctx, &expectedStatuses, false)
-		if !diags.HasError() {
-			for _, expectedStatus := range expectedStatuses {
-				if int(expectedStatus) == statusCode {
-					action := "continue"
-					if !data.OnSuccess.IsNull() {
-						action = data.OnSuccess.ValueString()
-					}
-					return true, action
-				}
-			}
-			// If expected statuses are specified but this code isn't in the list, treat as failure
-			action := "fail"
-			if !data.OnFailure.IsNull() {
-				action = data.OnFailure.ValueString()
-			}
-			return false, action
-		}
-	}
-
-	// Default behavior: 2xx codes are successful
-	if statusCode >= 200 && statusCode < 300 {
-		action := "continue"
-		if !data.OnSuccess.IsNull() {
-			action = data.OnSuccess.ValueString()
-		}
-		return true, action
-	}
-
-	// Non-2xx codes are failures by default
-	action := "fail"
-	if !data.OnFailure.IsNull() {
-		action = data.OnFailure.ValueString()
-	}
-	return false, action
+// buildRequestOptions creates client.RequestOptions from resource model
+func (r *RestResource) buildRequestOptions(ctx context.Context, data *RestResourceModel, method string, body string) client.RequestOptions {
+	return newRequestOptions(method, data.Endpoint.ValueString(), body, requestSettings{
+		Headers:            data.Headers,
+		SensitiveHeaders:   data.SensitiveHeaders,
+		QueryParams:        data.QueryParams,
+		RepeatedHeaders:    data.RepeatedHeaders,
+		RepeatedQuery:      data.RepeatedQuery,
+		RawQuery:           data.RawQuery,
+		Timeout:            data.Timeout,
+		RetryAttempts:      data.RetryAttempts,
+		Insecure:           data.Insecure,
+		MaxResponseBytes:   data.MaxResponseBytes,
+		StreamResponse:     data.StreamResponse,
+		ResponseSpoolPath:  data.ResponseSpoolPath,
+		RequestCompression: data.RequestCompression,
+	})
 }
 
-// shouldRetryOnStatus checks if the status code should trigger a retry
-func (r *RestResource) shouldRetryOnStatus(ctx context.Context, statusCode int, data *RestResourceModel) bool {
-	// Check custom retry status codes
-	if !data.RetryOnStatus.IsNull() {
-		retryStatuses := make([]int64, 0, len(data.RetryOnStatus.Elements()))
-		diags := data.RetryOnStatus.ElementsAs(ctx, &retryStatuses, false)
-		if !diags.HasError() {
-			for _, retryStatus := range retryStatuses {
-				if int(retryStatus) == statusCode {
-					return true
-				}
-			}
-		}
-	}
-
-	// Standard retryable status codes (429, 5xx)
-	return statusCode == 429 || (statusCode >= 500 && statusCode < 600)
+// readWriteOnlyConfig copies write-only attributes from configuration into the
+// model; plan and state always hold null for them
+func (r *RestResource) readWriteOnlyConfig(ctx context.Context, config tfsdk.Config, data *RestResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	diags.Append(config.GetAttribute(ctx, path.Root("body_wo"), &data.BodyWO)...)
+	diags.Append(config.GetAttribute(ctx, path.Root("sensitive_headers"), &data.SensitiveHeaders)...)
+	return diags
 }
 
-// buildRequestOptions creates client.RequestOptions from resource model
-func (r *RestResource) buildRequestOptions(ctx context.Context, data *RestResourceModel, method string, body string) client.RequestOptions {
-	options := client.RequestOptions{
-		Method:   method,
-		Endpoint: data.Endpoint.ValueString(),
+// addRequestError records a diagnostic for a failed HTTP request
+func addRequestError(diags *diag.Diagnostics, err error, method, endpoint string) {
+	var tooLarge *client.ResponseTooLargeError
+	if errors.As(err, &tooLarge) {
+		diags.AddError(
+			"Response Too Large",
+			fmt.Sprintf("The response to %s %s is larger than the %d byte limit. Raise max_response_bytes, or set stream_response = true to record only the response size and SHA-256 digest.", method, endpoint, tooLarge.Limit),
+		)
+		return
 	}
 
-	// Add body if provided
-	if body != "" {
-		options.Body = []byte(body)
-	}
+	diags.AddError(
+		"HTTP Request Failed",
+		fmt.Sprintf("Unable to send %s request to %s: %s", method, endpoint, err),
+	)
+}
 
-	// Add custom headers
-	if data.Headers != nil {
-		customHeaders := make(map[string]string)
-		for key, value := range data.Headers {
-			customHeaders[key] = value.ValueString()
-		}
-		options.Headers = customHeaders
+// stringListMapValues converts a map of string lists from the model into plain Go values
+func stringListMapValues(values map[string][]types.String) map[string][]string {
+	if values == nil {
+		return nil
 	}
 
-	// Add query parameters
-	if data.QueryParams != nil {
-		queryParams := make(map[string]string)
-		for key, value := range data.QueryParams {
-			queryParams[key] = value.ValueString()
+	result := make(map[string][]string, len(values))
+	for key, list := range values {
+		items := make([]string, 0, len(list))
+		for _, item := range list {
+			items = append(items, item.ValueString())
 		}
-		options.QueryParams = queryParams
+		result[key] = items
 	}
+	return result
+}
 
-	// Set timeout if provided
-	if !data.Timeout.IsNull() {
-		options.Timeout = time.Duration(data.Timeout.ValueInt64()) * time.Second
+// processResponse handles the HTTP response and updates the model
+func (r *RestResource) processResponse(ctx context.Context, response *client.Response, data *RestResourceModel) error {
+	if err := r.recordResponse(ctx, response, data); err != nil {
+		return err
 	}
+	return r.assignID(ctx, response, data)
+}
 
-	// Set retry attempts if provided
-	if !data.RetryAttempts.IsNull() {
-		options.Retries = int(data.RetryAttempts.ValueInt64())
+// recordResponse stores the response attributes in the model, leaving the ID
+// to assignID
+func (r *RestResource) recordResponse(ctx context.Context, response *client.Response, data *RestResourceModel) error {
+	// Streamed responses keep their body out of state, including bodies read
+	// in full for waiting or lookups
+	body := response.Body
+	if data.StreamResponse.ValueBool() {
+		body = nil
+	}
+
+	// Strip redacted fields before anything from the body reaches state
+	if !data.RedactResponsePaths.IsNull() && len(body) > 0 {
+		var patterns []string
+		if diags := data.RedactResponsePaths.ElementsAs(ctx, &patterns, false); diags.HasError() {
+			return fmt.Errorf("failed to read redact_response_paths: %v", diags.Errors())
+		}
+		redacted, err := redactJSONBody(body, patterns)
+		if err != nil {
+			return err
+		}
+		body = redacted
 	}
 
-	return options
-}
-
-// processResponse handles the HTTP response and updates the model
-func (r *RestResource) processResponse(ctx context.Context, response *client.Response, data *RestResourceModel) error {
 	// Set response data
 	data.StatusCode = types.Int64Value(int64(response.StatusCode))
-	data.Response = types.StringValue(string(response.Body))
+	responseBody := types.StringValue(string(body))
+	data.ResponseSHA256 = types.StringValue(response.SHA256)
+	data.ResponseSize = types.Int64Value(response.Size)
 
 	// Set response headers
-	responseHeaders := make(map[string]attr.Value)
-	for key, values := range response.Headers {
-		if len(values) > 0 {
-			responseHeaders[key] = types.StringValue(values[0])
-		}
-	}
-	headersMap, diags := types.MapValue(types.StringType, responseHeaders)
-	if diags.HasError() {
-		tflog.Warn(ctx, "failed to create response headers map", map[string]interface{}{
-			"errors": diags.Errors(),
-		})
-		data.ResponseHeaders = types.MapNull(types.StringType)
+	data.ResponseHeaders = responseHeadersValue(ctx, response.Headers)
+
+	// Parse JSON response data for dynamic output; streamed responses have no
+	// body to parse
+	dataMap := responseDataValue(ctx, body)
+
+	// Sensitive responses are kept out of the plain attributes entirely
+	if data.SensitiveResponse.ValueBool() {
+		data.Response = types.StringNull()
+		data.ResponseData = types.MapNull(types.StringType)
+		data.ResponseObject = types.DynamicNull()
+		data.SensitiveResponseBody = responseBody
+		data.SensitiveResponseData = dataMap
 	} else {
-		data.ResponseHeaders = headersMap
+		data.Response = responseBody
+		data.ResponseData = dataMap
+		data.ResponseObject = responseObjectValue(ctx, body)
+		data.SensitiveResponseBody = types.StringNull()
+		data.SensitiveResponseData = types.MapNull(types.StringType)
 	}
 
-	// Parse JSON response data for dynamic output
-	responseData := make(map[string]attr.Value)
-	if len(response.Body) > 0 {
-		var parsed map[string]interface{}
-		if err := json.Unmarshal(response.Body, &parsed); err == nil {
-			for key, value := range parsed {
-				// Convert all values to strings for simplicity
-				if value != nil {
-					switch v := value.(type) {
-					case string:
-						responseData[key] = types.StringValue(v)
-					case float64:
-						responseData[key] = types.StringValue(fmt.Sprintf("%.0f", v))
-					case bool:
-						responseData[key] = types.StringValue(fmt.Sprintf("%t", v))
-					default:
-						// Convert complex types to JSON string
-						if jsonBytes, err := json.Marshal(value); err == nil {
-							responseData[key] = types.StringValue(string(jsonBytes))
-						}
-					}
-				}
-			}
-		}
-	}
-	dataMap, diags := types.MapValue(types.StringType, responseData)
+	// Drift is only reported by refreshes
+	data.DriftReport = driftReportValue(ctx, nil)
+
+	// Record the object version for the next update or delete
+	data.ConcurrencyToken = concurrencyToken(data.OptimisticLocking, response)
+
+	// Set request timings
+	timings, diags := timingsObjectValue(response.Timings)
 	if diags.HasError() {
-		tflog.Warn(ctx, "failed to create response data map", map[string]interface{}{
+		tflog.Warn(ctx, "failed to create timings object", map[string]interface{}{
 			"errors": diags.Errors(),
 		})
-		data.ResponseData = types.MapNull(types.StringType)
+		data.Timings = types.ObjectNull(timingsAttrTypes)
 	} else {
-		data.ResponseData = dataMap
+		data.Timings = timings
 	}
 
 	// Set timestamps
@@ -436,19 +740,43 @@ func (r *RestResource) processResponse(ctx context.Context, response *client.Res
 	}
 	data.LastUpdated = types.StringValue(currentTime)
 
-	// Extract ID from response if it exists and it's not already set
+	return nil
+}
+
+// assignID sets the resource ID from the response when it is not yet known,
+// falling back to self_link or endpoint_name. A nil response goes straight to
+// the fallbacks. Without any of them the object cannot be addressed, which is
+// an error.
+func (r *RestResource) assignID(ctx context.Context, response *client.Response, data *RestResourceModel) error {
 	if data.Id.IsNull() || data.Id.IsUnknown() {
-		if len(response.Body) > 0 {
-			var parsed map[string]interface{}
-			if err := json.Unmarshal(response.Body, &parsed); err == nil {
-				if idValue, ok := parsed["id"].(string); ok {
-					data.Id = types.StringValue(idValue)
-					tflog.Debug(ctx, "extracted ID from response", map[string]interface{}{
-						"id": idValue,
-					})
-					return nil
-				}
+		if response != nil {
+			idValue, found, err := r.responseID(data, response)
+			if err != nil {
+				return err
+			}
+			if found {
+				data.Id = types.StringValue(idValue)
+				tflog.Debug(ctx, "extracted ID from response", map[string]interface{}{
+					"id": idValue,
+				})
+				return nil
+			}
+		}
+
+		// Without a name there is nothing stable to address the object by
+		// other than the Location header
+		if data.Name.IsNull() && !data.SelfLink.IsNull() && !data.SelfLink.IsUnknown() {
+			data.Id = data.SelfLink
+			tflog.Debug(ctx, "using self_link as ID", map[string]interface{}{
+				"id": data.SelfLink.ValueString(),
+			})
+			return nil
+		}
+		if data.Name.IsNull() {
+			if data.Poll != nil {
+				return fmt.Errorf("the response has no resource ID and name is not set; set id_attribute or id_from_header to locate the ID, poll result_id_path or result_link_path to take it from the operation result, or set name")
 			}
+			return fmt.Errorf("the response has no resource ID and name is not set; set id_attribute or id_from_header to locate the ID, or set name")
 		}
 
 		// Fallback ID generation only if no ID was found in response
@@ -462,6 +790,113 @@ func (r *RestResource) processResponse(ctx context.Context, response *client.Res
 	return nil
 }
 
+// responseDataValue flattens the top-level fields of a JSON object body into
+// the response_data map. Nested values are JSON encoded; bodies that are not
+// JSON objects give an empty map.
+func responseDataValue(ctx context.Context, body []byte) types.Map {
+	var parsed map[string]interface{}
+	if len(body) > 0 {
+		// Numbers are kept as written so decimals and large IDs survive
+		if document, err := decodeJSONDocument(body); err == nil {
+			parsed, _ = document.(map[string]interface{})
+		}
+	}
+
+	responseData := make(map[string]attr.Value)
+	for key, value := range parsed {
+		// Convert all values to strings for simplicity
+		if value == nil {
+			continue
+		}
+		if text, ok := jsonScalarString(value); ok {
+			responseData[key] = types.StringValue(text)
+		} else if jsonBytes, err := json.Marshal(value); err == nil {
+			// Convert complex types to JSON string
+			responseData[key] = types.StringValue(string(jsonBytes))
+		}
+	}
+	dataMap, diags := types.MapValue(types.StringType, responseData)
+	if diags.HasError() {
+		tflog.Warn(ctx, "failed to create response data map", map[string]interface{}{
+			"errors": diags.Errors(),
+		})
+		return types.MapNull(types.StringType)
+	}
+	return dataMap
+}
+
+// responseID determines the resource ID from a response. It returns found=false
+// when the default top-level "id" field is absent, and an error when a configured
+// id_from_header or id_attribute source cannot be satisfied.
+func (r *RestResource) responseID(data *RestResourceModel, response *client.Response) (string, bool, error) {
+	// Header-based extraction takes precedence
+	if data.IdFromHeader != nil {
+		headerName := data.IdFromHeader.Name.ValueString()
+		headerValue := http.Header(response.Headers).Get(headerName)
+		if headerValue == "" {
+			return "", false, fmt.Errorf("response has no %s header to extract the resource ID from", headerName)
+		}
+
+		if data.IdFromHeader.Pattern.IsNull() {
+			return headerValue, true, nil
+		}
+
+		pattern, err := regexp.Compile(data.IdFromHeader.Pattern.ValueString())
+		if err != nil {
+			return "", false, fmt.Errorf("invalid id_from_header pattern: %w", err)
+		}
+		match := pattern.FindStringSubmatch(headerValue)
+		if match == nil {
+			return "", false, fmt.Errorf("%s header %q does not match id_from_header pattern %q", headerName, headerValue, pattern.String())
+		}
+		if len(match) > 1 {
+			return match[1], true, nil
+		}
+		return match[0], true, nil
+	}
+
+	configured := !data.IdAttribute.IsNull()
+	idPath := "id"
+	if configured {
+		idPath = data.IdAttribute.ValueString()
+	}
+
+	var document interface{}
+	if len(response.Body) > 0 {
+		if decoded, err := decodeJSONDocument(response.Body); err == nil {
+			document = decoded
+		}
+	}
+
+	value, found, err := lookupJSONPath(document, idPath)
+	if err != nil {
+		return "", false, fmt.Errorf("invalid id_attribute: %w", err)
+	}
+	if found {
+		if idValue, ok := jsonScalarString(value); ok && idValue != "" {
+			return idValue, true, nil
+		}
+		if configured {
+			return "", false, fmt.Errorf("id_attribute %q must be a string, number or boolean, got %s", idPath, string(mustMarshalJSON(value)))
+		}
+		return "", false, nil
+	}
+
+	if configured {
+		return "", false, fmt.Errorf("response body has no value at id_attribute %q", idPath)
+	}
+	return "", false, nil
+}
+
+// mustMarshalJSON renders a decoded JSON value for diagnostics
+func mustMarshalJSON(value interface{}) []byte {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return []byte(fmt.Sprintf("%v", value))
+	}
+	return encoded
+}
+
 func (r *RestResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data RestResourceModel
 
@@ -480,12 +915,42 @@ func (r *RestResource) Create(ctx context.Context, req resource.CreateRequest, r
 		data.Method = types.StringValue(method)
 	}
 
+	// Write-only values are only present in configuration
+	resp.Diagnostics.Append(r.readWriteOnlyConfig(ctx, req.Config, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Get request body
 	requestBody := ""
-	if !data.Body.IsNull() {
+	if !data.BodyWO.IsNull() {
+		requestBody = data.BodyWO.ValueString()
+	} else if !data.Body.IsNull() {
 		requestBody = data.Body.ValueString()
 	}
 
+	// The object has no location of its own until the API reports one
+	data.SelfLink = types.StringNull()
+	data.HookResponses = types.MapNull(types.ObjectType{AttrTypes: hookResponseAttrTypes})
+
+	// Look for an object that already exists before creating another
+	if data.CheckExistsBeforeCreate.ValueBool() {
+		existing, err := r.findExistingObject(ctx, &data)
+		if err != nil {
+			resp.Diagnostics.AddError("Existence Check Failed", err.Error())
+			return
+		}
+		if existing != nil {
+			r.createFromExisting(ctx, &data, existing, resp)
+			return
+		}
+	}
+
+	r.runHook(ctx, &data, "pre_create", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Build request options
 	options := r.buildRequestOptions(ctx, &data, method, requestBody)
 
@@ -494,54 +959,112 @@ func (r *RestResource) Create(ctx context.Context, req resource.CreateRequest, r
 		"endpoint": data.Endpoint.ValueString(),
 	})
 
-	// Make the request
-	response, err := r.client.Do(ctx, options)
+	policy, err := r.statusPolicy(ctx, &data, "create")
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"HTTP Request Failed",
-			fmt.Sprintf("Unable to send %s request to %s: %s", method, data.Endpoint.ValueString(), err),
-		)
+		resp.Diagnostics.AddError("Invalid Status Policy", err.Error())
 		return
 	}
 
-	// Check status code using conditional logic
-	isSuccess, action := r.checkStatusCode(ctx, response.StatusCode, &data)
+	// Make the request
+	response, decision, err := doWithStatusPolicy(ctx, r.client, options, policy)
+	if err != nil {
+		addRequestError(&resp.Diagnostics, err, method, data.Endpoint.ValueString())
+		return
+	}
 
-	if !isSuccess {
-		switch action {
-		case "continue":
-			tflog.Warn(ctx, "continuing despite failed status code", map[string]interface{}{
-				"status_code": response.StatusCode,
-				"response":    string(response.Body),
-			})
-		case "retry":
-			if r.shouldRetryOnStatus(ctx, response.StatusCode, &data) {
-				resp.Diagnostics.AddError(
-					"API Error - Retryable",
-					fmt.Sprintf("Received retryable response code: %d, Response: %s", response.StatusCode, string(response.Body)),
-				)
-				return
-			}
-			fallthrough
-		default: // "fail"
+	// The object already exists; take it over if on_create_conflict allows
+	if response.StatusCode == http.StatusConflict && createConflictMode(&data) != createConflictFail {
+		existing, err := r.findExistingObject(ctx, &data)
+		if err != nil {
+			resp.Diagnostics.AddError("Existence Check Failed", fmt.Sprintf("The create request returned 409 Conflict, and %s", err))
+			return
+		}
+		if existing == nil {
 			resp.Diagnostics.AddError(
 				"API Error",
-				fmt.Sprintf("Received non-success response code: %d, Response: %s", response.StatusCode, string(response.Body)),
+				fmt.Sprintf("Received 409 Conflict but no existing object was found to %s. Response: %s", createConflictMode(&data), string(response.Body)),
 			)
 			return
 		}
-	} else if action == "stop" {
+		r.createFromExisting(ctx, &data, existing, resp)
+		return
+	}
+
+	// Check the status against the create status policy
+	if err := checkFailedStatus(ctx, response, decision); err != nil {
+		resp.Diagnostics.AddError("API Error", err.Error())
+		return
+	}
+	if decision.stop() {
 		tflog.Info(ctx, "stopping processing due to success action", map[string]interface{}{
 			"status_code": response.StatusCode,
 		})
 	}
 
-	// Process response
-	if err := r.processResponse(ctx, response, &data); err != nil {
+	// Record where the API says the object lives
+	if data.UseLocationHeader.ValueBool() {
+		location := http.Header(response.Headers).Get("Location")
+		if location == "" {
+			resp.Diagnostics.AddError(
+				"Missing Location Header",
+				fmt.Sprintf("use_location_header is enabled but the %d response to %s %s has no Location header.", response.StatusCode, method, data.Endpoint.ValueString()),
+			)
+			return
+		}
+
+		selfLink, err := r.client.ResolveLocation(response, location)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid Location Header", err.Error())
+			return
+		}
+		data.SelfLink = types.StringValue(selfLink.String())
+	}
+
+	// A 202 response describes the operation rather than the object, so the ID
+	// is only required once the operation has finished
+	polling := !decision.stop() && r.shouldPoll(&data, response)
+	if polling {
+		err = r.recordResponse(ctx, response, &data)
+	} else {
+		err = r.processResponse(ctx, response, &data)
+	}
+	if err != nil {
 		resp.Diagnostics.AddError("Response Processing Error", err.Error())
 		return
 	}
 
+	// Follow a long-running operation to completion. The object exists from
+	// here on, so save it even if the operation fails and let Terraform taint
+	// it, as long as it can be addressed
+	if polling {
+		if err := r.completeOperation(ctx, &data, response, true); err != nil {
+			resp.Diagnostics.AddError("Operation Failed", err.Error())
+			if r.assignID(ctx, nil, &data) == nil {
+				resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			}
+			return
+		}
+		if err := r.assignID(ctx, nil, &data); err != nil {
+			resp.Diagnostics.AddError("Response Processing Error", fmt.Sprintf("The operation finished, but %s", err))
+			return
+		}
+	}
+
+	r.runHook(ctx, &data, "post_create", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	// Wait for the object to become usable
+	if !decision.stop() && data.WaitForReady != nil {
+		if err := r.waitForReady(ctx, &data); err != nil {
+			resp.Diagnostics.AddError("Resource Not Ready", err.Error())
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			return
+		}
+	}
+
 	tflog.Trace(ctx, "created REST resource", map[string]interface{}{
 		"method":      method,
 		"endpoint":    data.Endpoint.ValueString(),
@@ -562,10 +1085,11 @@ func (r *RestResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	// Build URL for GET request - typically append name to endpoint
-	endpoint := data.Endpoint.ValueString()
-	if !data.Name.IsNull() {
-		endpoint = fmt.Sprintf("%s/%s", endpoint, data.Name.ValueString())
+	// Build URL for GET request from the path templates or endpoint/name
+	endpoint, err := r.operationPath(&data, "read")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Object Path", err.Error())
+		return
 	}
 
 	// Resolve the HTTP method for read operation
@@ -576,7 +1100,7 @@ func (r *RestResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 	// Build request options with resolved method
 	options := r.buildRequestOptions(ctx, &data, method, "")
-	// Override endpoint for read operation (with name appended)
+	// Override endpoint for read operation
 	options.Endpoint = endpoint
 
 	tflog.Trace(ctx, "reading REST resource", map[string]interface{}{
@@ -584,29 +1108,50 @@ func (r *RestResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		"id":       data.Id.ValueString(),
 	})
 
-	// Make the request
-	response, err := r.client.Do(ctx, options)
+	policy, err := r.statusPolicy(ctx, &data, "read")
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"HTTP Request Failed",
-			fmt.Sprintf("Unable to send GET request to %s: %s", endpoint, err),
-		)
+		resp.Diagnostics.AddError("Invalid Status Policy", err.Error())
 		return
 	}
 
-	// Handle 404 as resource not found
-	if response.StatusCode == 404 {
-		resp.State.RemoveResource(ctx)
+	// Make the request, or find the object in its collection
+	var response *client.Response
+	var decision statusDecision
+	if data.ReadFromList != nil {
+		response, decision, endpoint, err = r.readFromList(ctx, &data)
+	} else {
+		response, decision, err = doWithStatusPolicy(ctx, r.client, options, policy)
+	}
+	if err != nil {
+		addRequestError(&resp.Diagnostics, err, method, endpoint)
 		return
 	}
 
-	// Check for other success status codes
-	if response.StatusCode < 200 || response.StatusCode >= 300 {
-		resp.Diagnostics.AddError(
-			"API Error",
-			fmt.Sprintf("Received non-success response code: %d, Response: %s", response.StatusCode, string(response.Body)),
-		)
+	switch decision.outcome {
+	case statusGone:
+		// The object no longer exists; plan to create it again
+		resp.State.RemoveResource(ctx)
 		return
+	case statusFailed:
+		if err := checkFailedStatus(ctx, response, decision); err != nil {
+			resp.Diagnostics.AddError("API Error", err.Error())
+			return
+		}
+	case statusSucceeded:
+		// Some APIs answer soft-deleted objects with a success status
+		gone, state, err := isGoneResponse(&data, response)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid Gone Condition", err.Error())
+			return
+		}
+		if gone {
+			tflog.Info(ctx, "object matches gone_when, removing it from state", map[string]interface{}{
+				"endpoint": endpoint,
+				"state":    state,
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
 	}
 
 	// Process the response using the same logic as Create/Update
@@ -616,11 +1161,16 @@ func (r *RestResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	}
 
 	// Perform drift detection if enabled
-	if err := r.performDriftDetection(ctx, &data, response); err != nil {
-		tflog.Warn(ctx, "drift detection warning", map[string]interface{}{
-			"error": err.Error(),
-		})
-		// Continue execution - drift detection errors are warnings, not failures
+	if !decision.stop() {
+		if err := r.performDriftDetection(ctx, &data, response, &resp.Diagnostics); err != nil {
+			tflog.Warn(ctx, "drift detection warning", map[string]interface{}{
+				"error": err.Error(),
+			})
+			// Continue execution - drift detection errors are warnings, not failures
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	}
 
 	tflog.Trace(ctx, "read REST resource", map[string]interface{}{
@@ -642,62 +1192,104 @@ func (r *RestResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
-	// Build URL for PUT/PATCH request - typically append name to endpoint
-	endpoint := data.Endpoint.ValueString()
-	if !data.Name.IsNull() {
-		endpoint = fmt.Sprintf("%s/%s", endpoint, data.Name.ValueString())
+	// The ID and response fields are only known from prior state; carry them
+	// forward so path templates can reference them
+	var state RestResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	data.Id = state.Id
+	data.ResponseData = state.ResponseData
+	data.ResponseObject = state.ResponseObject
+	data.SensitiveResponseData = state.SensitiveResponseData
+	data.SelfLink = state.SelfLink
+	data.ConcurrencyToken = state.ConcurrencyToken
+	data.HookResponses = state.HookResponses
 
-	// Resolve the HTTP method for update operation
-	method := r.resolveMethodForOperation(&data, "update")
-
-	// Set computed value for visibility
-	data.UpdateMethod = types.StringValue(method)
+	// Write-only values are only present in configuration
+	resp.Diagnostics.Append(r.readWriteOnlyConfig(ctx, req.Config, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	// Get update body - prefer update_body, fallback to body
-	requestBody := ""
-	if !data.UpdateBody.IsNull() {
-		requestBody = data.UpdateBody.ValueString()
-	} else if !data.Body.IsNull() {
-		requestBody = data.Body.ValueString()
+	r.runHook(ctx, &data, "pre_update", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	// Build request options
-	options := client.RequestOptions{
-		Method:   method,
-		Endpoint: endpoint,
+	// Patch strategies send the difference from the body in state
+	decision := r.sendUpdate(ctx, &data, updateDocument(&state), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	if requestBody != "" {
-		options.Body = []byte(requestBody)
+	r.runHook(ctx, &data, "post_update", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	// Add custom headers
-	if data.Headers != nil {
-		customHeaders := make(map[string]string)
-		for key, value := range data.Headers {
-			customHeaders[key] = value.ValueString()
+	// Wait for the object to become usable again
+	if !decision.stop() && data.WaitForReady != nil {
+		if err := r.waitForReady(ctx, &data); err != nil {
+			resp.Diagnostics.AddError("Resource Not Ready", err.Error())
+			return
 		}
-		options.Headers = customHeaders
 	}
 
-	// Add query parameters
-	if data.QueryParams != nil {
-		queryParams := make(map[string]string)
-		for key, value := range data.QueryParams {
-			queryParams[key] = value.ValueString()
+	// Save updated state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// sendUpdate sends the update request for the planned body and records the
+// response, following a long-running operation to completion unless the status
+// policy says to stop. prior is the body the object currently has, from which
+// patch strategies compute the patch.
+func (r *RestResource) sendUpdate(ctx context.Context, data *RestResourceModel, prior JSONBody, diags *diag.Diagnostics) (decision statusDecision) {
+	// Build URL for PUT/PATCH request from the path templates or endpoint/name
+	endpoint, err := r.operationPath(data, "update")
+	if err != nil {
+		diags.AddError("Invalid Object Path", err.Error())
+		return
+	}
+
+	// Resolve the HTTP method for update operation
+	method := r.resolveMethodForOperation(data, "update")
+
+	// Set computed value for visibility
+	data.UpdateMethod = types.StringValue(method)
+
+	// Get update body - prefer update_body, then body_wo, fallback to body.
+	// Patch strategies send the difference from the prior body instead.
+	requestBody := updateDocument(data).ValueString()
+	contentType := ""
+	if strategy := updateStrategy(data); strategy != updateStrategyPutFull {
+		requestBody, contentType, err = buildPatchBody(strategy, prior, updateDocument(data))
+		if err != nil {
+			diags.AddError("Unable to Compute Patch", err.Error())
+			return
 		}
-		options.QueryParams = queryParams
 	}
 
-	// Set timeout if provided
-	if !data.Timeout.IsNull() {
-		options.Timeout = time.Duration(data.Timeout.ValueInt64()) * time.Second
+	// Build request options
+	options := r.buildRequestOptions(ctx, data, method, requestBody)
+	// Override endpoint for update operation
+	options.Endpoint = endpoint
+	if contentType != "" && !hasHeader(options.Headers, "Content-Type") {
+		if options.Headers == nil {
+			options.Headers = make(map[string]string)
+		}
+		options.Headers["Content-Type"] = contentType
 	}
 
-	// Set retry attempts if provided
-	if !data.RetryAttempts.IsNull() {
-		options.Retries = int(data.RetryAttempts.ValueInt64())
+	// Only update the version of the object Terraform last read
+	if err := r.applyConcurrencyToken(ctx, data, &options, "update"); err != nil {
+		if errors.Is(err, errObjectChanged) {
+			addPreconditionFailed(diags, method, endpoint)
+		} else {
+			diags.AddError("Optimistic Locking Error", err.Error())
+		}
+		return
 	}
 
 	tflog.Trace(ctx, "updating REST resource", map[string]interface{}{
@@ -706,31 +1298,54 @@ func (r *RestResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		"id":       data.Id.ValueString(),
 	})
 
+	policy, err := r.statusPolicy(ctx, data, "update")
+	if err != nil {
+		diags.AddError("Invalid Status Policy", err.Error())
+		return
+	}
+
 	// Make the request
-	response, err := r.client.Do(ctx, options)
+	response, decision, err := doWithStatusPolicy(ctx, r.client, options, policy)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"HTTP Request Failed",
-			fmt.Sprintf("Unable to send %s request to %s: %s", method, endpoint, err),
-		)
+		addRequestError(diags, err, method, endpoint)
 		return
 	}
 
-	// Check for successful status codes
-	if response.StatusCode < 200 || response.StatusCode >= 300 {
-		resp.Diagnostics.AddError(
-			"API Error",
-			fmt.Sprintf("Received non-success response code: %d, Response: %s", response.StatusCode, string(response.Body)),
+	// A rejected version check means someone else changed the object
+	if response.StatusCode == http.StatusPreconditionFailed && data.OptimisticLocking != nil {
+		addPreconditionFailed(diags, method, endpoint)
+		return
+	}
+
+	// Check the status against the update status policy
+	switch decision.outcome {
+	case statusGone:
+		diags.AddError(
+			"Object Gone",
+			fmt.Sprintf("%s %s returned %d: the object no longer exists. Run terraform plan again to refresh state and plan to recreate it.", method, endpoint, response.StatusCode),
 		)
 		return
+	case statusFailed:
+		if err := checkFailedStatus(ctx, response, decision); err != nil {
+			diags.AddError("API Error", err.Error())
+			return
+		}
 	}
 
 	// Process response
-	if err := r.processResponse(ctx, response, &data); err != nil {
-		resp.Diagnostics.AddError("Response Processing Error", err.Error())
+	if err := r.processResponse(ctx, response, data); err != nil {
+		diags.AddError("Response Processing Error", err.Error())
 		return
 	}
 
+	// Follow a long-running operation to completion
+	if !decision.stop() && r.shouldPoll(data, response) {
+		if err := r.completeOperation(ctx, data, response, true); err != nil {
+			diags.AddError("Operation Failed", err.Error())
+			return
+		}
+	}
+
 	tflog.Trace(ctx, "updated REST resource", map[string]interface{}{
 		"method":      method,
 		"endpoint":    endpoint,
@@ -738,8 +1353,32 @@ func (r *RestResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		"id":          data.Id.ValueString(),
 	})
 
-	// Save updated state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	return decision
+}
+
+// UpgradeState migrates state from earlier schema versions. Version 0 stored the
+// bodies as plain strings; the stored text is kept as is so existing
+// configurations plan no changes, and attributes added since are null.
+func (r *RestResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				rawState, err := req.RawState.UnmarshalWithOpts(resp.State.Schema.Type().TerraformType(ctx), tfprotov6.UnmarshalOpts{
+					ValueFromJSONOpts: tftypes.ValueFromJSONOpts{
+						IgnoreUndefinedAttributes: true,
+					},
+				})
+				if err != nil {
+					resp.Diagnostics.AddError(
+						"Unable to Upgrade Resource State",
+						fmt.Sprintf("Could not read the version 0 state of this resource: %s", err),
+					)
+					return
+				}
+				resp.State.Raw = rawState
+			},
+		},
+	}
 }
 
 func (r *RestResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
@@ -781,10 +1420,16 @@ func (r *RestResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
-	// Build URL for DELETE request - typically append name to endpoint
-	endpoint := data.Endpoint.ValueString()
-	if !data.Name.IsNull() {
-		endpoint = fmt.Sprintf("%s/%s", endpoint, data.Name.ValueString())
+	// Build URL for DELETE request from the path templates or endpoint/name
+	endpoint, err := r.operationPath(&data, "delete")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Object Path", err.Error())
+		return
+	}
+
+	r.runHook(ctx, &data, "pre_delete", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	// Get destroy body if provided
@@ -800,41 +1445,18 @@ func (r *RestResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	data.DeleteMethod = types.StringValue(method)
 
 	// Build request options
-	options := client.RequestOptions{
-		Method:   method,
-		Endpoint: endpoint,
-	}
-
-	if requestBody != "" {
-		options.Body = []byte(requestBody)
-	}
-
-	// Add custom headers
-	if data.Headers != nil {
-		customHeaders := make(map[string]string)
-		for key, value := range data.Headers {
-			customHeaders[key] = value.ValueString()
-		}
-		options.Headers = customHeaders
-	}
+	options := r.buildRequestOptions(ctx, &data, method, requestBody)
+	// Override endpoint for delete operation
+	options.Endpoint = endpoint
 
-	// Add query parameters
-	if data.QueryParams != nil {
-		queryParams := make(map[string]string)
-		for key, value := range data.QueryParams {
-			queryParams[key] = value.ValueString()
+	// Only delete the version of the object Terraform last read
+	if err := r.applyConcurrencyToken(ctx, &data, &options, "delete"); err != nil {
+		if errors.Is(err, errObjectChanged) {
+			addPreconditionFailed(&resp.Diagnostics, method, endpoint)
+		} else {
+			resp.Diagnostics.AddError("Optimistic Locking Error", err.Error())
 		}
-		options.QueryParams = queryParams
-	}
-
-	// Set timeout if provided
-	if !data.Timeout.IsNull() {
-		options.Timeout = time.Duration(data.Timeout.ValueInt64()) * time.Second
-	}
-
-	// Set retry attempts if provided
-	if !data.RetryAttempts.IsNull() {
-		options.Retries = int(data.RetryAttempts.ValueInt64())
+		return
 	}
 
 	tflog.Trace(ctx, "deleting REST resource", map[string]interface{}{
@@ -842,31 +1464,71 @@ func (r *RestResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		"id":       data.Id.ValueString(),
 	})
 
+	policy, err := r.statusPolicy(ctx, &data, "delete")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Status Policy", err.Error())
+		return
+	}
+
 	// Make the request
-	response, err := r.client.Do(ctx, options)
+	response, decision, err := doWithStatusPolicy(ctx, r.client, options, policy)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"HTTP Request Failed",
-			fmt.Sprintf("Unable to send DELETE request to %s: %s", endpoint, err),
-		)
+		addRequestError(&resp.Diagnostics, err, method, endpoint)
+		return
+	}
+
+	// A rejected version check means someone else changed the object
+	if response.StatusCode == http.StatusPreconditionFailed && data.OptimisticLocking != nil {
+		addPreconditionFailed(&resp.Diagnostics, method, endpoint)
 		return
 	}
 
-	// Accept 200, 202, 204, and 404 as successful deletion
-	acceptableStatusCodes := []int{200, 202, 204, 404}
-	successful := false
-	for _, code := range acceptableStatusCodes {
-		if response.StatusCode == code {
-			successful = true
-			break
+	// Check the status against the delete status policy; an object that is
+	// already gone counts as deleted
+	switch decision.outcome {
+	case statusGone:
+		tflog.Debug(ctx, "object already deleted", map[string]interface{}{
+			"endpoint":    endpoint,
+			"status_code": response.StatusCode,
+		})
+		// A soft-deleted object may still be waiting to be purged
+		if deleteMode(&data) == deleteModeSoftThenPurge {
+			r.purgeObject(ctx, &data, &resp.Diagnostics)
+		}
+		return
+	case statusFailed:
+		if err := checkFailedStatus(ctx, response, decision); err != nil {
+			resp.Diagnostics.AddError("API Error", err.Error())
+			return
 		}
 	}
 
-	if !successful {
-		resp.Diagnostics.AddError(
-			"API Error",
-			fmt.Sprintf("Received non-success response code: %d, Response: %s", response.StatusCode, string(response.Body)),
-		)
+	// Wait for an asynchronous delete to finish
+	if !decision.stop() && r.shouldPoll(&data, response) {
+		if err := r.completeOperation(ctx, &data, response, false); err != nil {
+			resp.Diagnostics.AddError("Operation Failed", err.Error())
+			return
+		}
+	}
+
+	// Remove the soft-deleted object for good
+	if deleteMode(&data) == deleteModeSoftThenPurge {
+		r.purgeObject(ctx, &data, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Wait until the object is really gone so it can be recreated right away
+	if !decision.stop() && data.WaitForDelete != nil {
+		if err := r.waitForDelete(ctx, &data); err != nil {
+			resp.Diagnostics.AddError("Resource Not Deleted", err.Error())
+			return
+		}
+	}
+
+	r.runHook(ctx, &data, "post_delete", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
@@ -878,8 +1540,9 @@ func (r *RestResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 }
 
 // performDriftDetection compares the current API response with the expected state
-// to detect configuration drift while ignoring server-side metadata fields
-func (r *RestResource) performDriftDetection(ctx context.Context, data *RestResourceModel, response *client.Response) error {
+// to detect configuration drift while ignoring server-side metadata fields. Drift
+// is handled according to drift_action; warnings and errors go to diags.
+func (r *RestResource) performDriftDetection(ctx context.Context, data *RestResourceModel, response *client.Response, diags *diag.Diagnostics) error {
 	// Skip drift detection if disabled
 	if !data.DriftDetection.IsNull() && !data.DriftDetection.ValueBool() {
 		return nil
@@ -905,32 +1568,10 @@ func (r *RestResource) performDriftDetection(ctx context.Context, data *RestReso
 		return nil
 	}
 
-	// Get the list of fields to ignore during drift detection
-	ignoreFields := make(map[string]bool)
-
-	// Add default fields that are commonly server-managed
-	defaultIgnoreFields := []string{
-		"id", "created_at", "updated_at", "last_modified", "etag",
-		"version", "revision", "timestamp", "last_updated_at",
-		"created_by", "updated_by", "modified_by", "owner_id",
-		"_id", "_created", "_updated", "_modified", "_version",
-		"createdAt", "updatedAt", "lastModified", "lastUpdated",
-		"href", "self", "links", "_links", "meta", "_meta",
-	}
-
-	for _, field := range defaultIgnoreFields {
-		ignoreFields[field] = true
-	}
-
-	// Add user-specified ignore fields
-	if !data.IgnoreFields.IsNull() {
-		userIgnoreFields := make([]string, 0, len(data.IgnoreFields.Elements()))
-		diags := data.IgnoreFields.ElementsAs(ctx, &userIgnoreFields, false)
-		if !diags.HasError() {
-			for _, field := range userIgnoreFields {
-				ignoreFields[field] = true
-			}
-		}
+	comparator, err := r.driftComparator(ctx, data)
+	if err != nil {
+		diags.AddError("Invalid Drift Detection Rule", err.Error())
+		return nil
 	}
 
 	// Parse the expected response body if we have one
@@ -938,78 +1579,90 @@ func (r *RestResource) performDriftDetection(ctx context.Context, data *RestReso
 	if !data.Body.IsNull() && data.Body.ValueString() != "" {
 		if err := json.Unmarshal([]byte(data.Body.ValueString()), &expectedData); err != nil {
 			// Expected body is not JSON - compare raw strings
-			return r.compareRawResponse(ctx, data, string(response.Body), ignoreFields)
+			return r.compareRawResponse(ctx, data, string(response.Body))
 		}
 	} else {
 		// No expected body to compare against - just update computed fields
-		return r.updateComputedFields(ctx, data, currentData, ignoreFields)
+		return r.updateComputedFields(ctx, data, response)
 	}
 
 	// Compare the structured data
-	driftDetected := r.compareStructuredData(ctx, expectedData, currentData, ignoreFields, "")
+	drifted := comparator.compare(expectedData, currentData)
+	data.DriftReport = driftReportValue(ctx, drifted)
 
-	if driftDetected {
+	if len(drifted) > 0 {
+		paths := driftPaths(drifted)
 		tflog.Warn(ctx, "configuration drift detected", map[string]interface{}{
 			"resource_id": data.Id.ValueString(),
 			"endpoint":    data.Endpoint.ValueString(),
+			"fields":      paths,
 		})
-	}
-
-	// Always update computed fields regardless of drift
-	return r.updateComputedFields(ctx, data, currentData, ignoreFields)
-}
 
-// compareStructuredData recursively compares two JSON structures, ignoring specified fields
-func (r *RestResource) compareStructuredData(ctx context.Context, expected, current map[string]interface{}, ignoreFields map[string]bool, path string) bool {
-	driftDetected := false
-
-	// Check for missing or changed fields in expected data
-	for key, expectedValue := range expected {
-		fieldPath := key
-		if path != "" {
-			fieldPath = path + "." + key
+		action := "reconcile"
+		if !data.DriftAction.IsNull() {
+			action = data.DriftAction.ValueString()
 		}
 
-		// Skip ignored fields
-		if ignoreFields[key] {
-			continue
+		switch action {
+		case "error":
+			diags.AddError(
+				"Configuration Drift Detected",
+				fmt.Sprintf("The object for %s no longer matches body. Drifted fields: %s", data.Id.ValueString(), strings.Join(paths, ", ")),
+			)
+		case "warn":
+			diags.AddWarning(
+				"Configuration Drift Detected",
+				fmt.Sprintf("The object for %s no longer matches body. Drifted fields: %s", data.Id.ValueString(), strings.Join(paths, ", ")),
+			)
+		default: // "reconcile"
+			// Record the live values so the plan shows the difference from the
+			// configured body and schedules an update
+			projected, err := json.Marshal(comparator.project(expectedData, currentData))
+			if err != nil {
+				return fmt.Errorf("failed to encode drifted body: %w", err)
+			}
+			data.Body = NewJSONBodyValue(string(projected))
 		}
+	}
 
-		currentValue, exists := current[key]
-		if !exists {
-			tflog.Debug(ctx, "field missing in current response", map[string]interface{}{
-				"field":    fieldPath,
-				"expected": expectedValue,
-			})
-			driftDetected = true
-			continue
-		}
+	// Always update computed fields regardless of drift
+	return r.updateComputedFields(ctx, data, response)
+}
 
-		// Compare values based on type
-		if !r.valuesEqual(expectedValue, currentValue) {
-			tflog.Debug(ctx, "field value drift detected", map[string]interface{}{
-				"field":    fieldPath,
-				"expected": expectedValue,
-				"current":  currentValue,
-			})
-			driftDetected = true
-		}
+// driftComparator builds the comparator for the resource's ignore rules and
+// array keys
+func (r *RestResource) driftComparator(ctx context.Context, data *RestResourceModel) (*driftComparator, error) {
+	// Get the list of fields to ignore during drift detection, starting with
+	// fields that are commonly server-managed
+	var ignoreRules []string
+	if !data.DisableDefaultIgnoreFields.ValueBool() {
+		ignoreRules = append(ignoreRules, defaultIgnoreFields...)
+	}
 
-		// Recursively check nested objects
-		if expectedMap, ok := expectedValue.(map[string]interface{}); ok {
-			if currentMap, ok := currentValue.(map[string]interface{}); ok {
-				if r.compareStructuredData(ctx, expectedMap, currentMap, ignoreFields, fieldPath) {
-					driftDetected = true
-				}
-			}
+	// Add user-specified ignore fields
+	if !data.IgnoreFields.IsNull() {
+		userIgnoreFields := make([]string, 0, len(data.IgnoreFields.Elements()))
+		diags := data.IgnoreFields.ElementsAs(ctx, &userIgnoreFields, false)
+		if !diags.HasError() {
+			ignoreRules = append(ignoreRules, userIgnoreFields...)
 		}
 	}
 
-	return driftDetected
+	arrayKeys := make(map[string]string, len(data.ArrayKeyFields))
+	for path, field := range data.ArrayKeyFields {
+		arrayKeys[path] = field.ValueString()
+	}
+
+	comparator, err := newDriftComparator(ignoreRules, arrayKeys)
+	if err != nil {
+		return nil, err
+	}
+	comparator.ignoreMissing = data.DriftIgnoreMissingKeys.ValueBool()
+	return comparator, nil
 }
 
 // compareRawResponse compares raw string responses
-func (r *RestResource) compareRawResponse(ctx context.Context, data *RestResourceModel, currentResponse string, ignoreFields map[string]bool) error {
+func (r *RestResource) compareRawResponse(ctx context.Context, data *RestResourceModel, currentResponse string) error {
 	expectedResponse := data.Body.ValueString()
 
 	if expectedResponse != currentResponse {
@@ -1023,10 +1676,11 @@ func (r *RestResource) compareRawResponse(ctx context.Context, data *RestResourc
 }
 
 // updateComputedFields updates computed fields in the state based on the current response
-func (r *RestResource) updateComputedFields(ctx context.Context, data *RestResourceModel, currentData map[string]interface{}, ignoreFields map[string]bool) error {
-	// Update the ID if it exists in the response and is different
-	if currentId, ok := currentData["id"]; ok {
-		if idStr, ok := currentId.(string); ok {
+func (r *RestResource) updateComputedFields(ctx context.Context, data *RestResourceModel, response *client.Response) error {
+	// Update the ID if it exists in the response body and is different. Header-based
+	// IDs are usually only present on create responses, so they are left untouched.
+	if data.IdFromHeader == nil {
+		if idStr, found, err := r.responseID(data, response); err == nil && found {
 			expectedId := data.Id.ValueString()
 			if idStr != expectedId && expectedId != "" {
 				tflog.Debug(ctx, "updating resource ID from response", map[string]interface{}{
@@ -1049,37 +1703,6 @@ func (r *RestResource) updateComputedFields(ctx context.Context, data *RestResou
 	return nil
 }
 
-// valuesEqual compares two interface{} values for equality, handling different JSON number types
-func (r *RestResource) valuesEqual(a, b interface{}) bool {
-	if a == nil && b == nil {
-		return true
-	}
-	if a == nil || b == nil {
-		return false
-	}
-
-	// Handle numeric comparisons (JSON unmarshalling can create float64 or int)
-	if aNum, aOk := a.(float64); aOk {
-		if bNum, bOk := b.(float64); bOk {
-			return aNum == bNum
-		}
-		if bNum, bOk := b.(int); bOk {
-			return aNum == float64(bNum)
-		}
-	}
-	if aNum, aOk := a.(int); aOk {
-		if bNum, bOk := b.(float64); bOk {
-			return float64(aNum) == bNum
-		}
-		if bNum, bOk := b.(int); bOk {
-			return aNum == bNum
-		}
-	}
-
-	// Direct comparison for other types
-	return a == b
-}
-
 // resolveMethodForOperation determines the HTTP method to use for a given operation
 // Supports backward compatibility with the legacy 'method' field
 func (r *RestResource) resolveMethodForOperation(data *RestResourceModel, operation string) string {
@@ -1112,6 +1735,10 @@ func (r *RestResource) resolveMethodForOperation(data *RestResourceModel, operat
 		if !data.UpdateMethod.IsNull() && !data.UpdateMethod.IsUnknown() {
 			return data.UpdateMethod.ValueString()
 		}
+		// Patch documents are sent with PATCH
+		if updateStrategy(data) != updateStrategyPutFull {
+			return "PATCH"
+		}
 		// Backward compatibility: use PATCH if legacy method was PATCH, otherwise PUT
 		if !data.Method.IsNull() && !data.Method.IsUnknown() {
 			method := data.Method.ValueString()
@@ -1138,3 +1765,67 @@ func (r *RestResource) resolveMethodForOperation(data *RestResourceModel, operat
 		return "GET" // Safe default
 	}
 }
+
+// operationPath builds the request path for a read, update or delete operation.
+// An operation-specific template wins over self_link, then object_path; without
+// any of them the path is endpoint/name, or endpoint/id when name is not set.
+func (r *RestResource) operationPath(data *RestResourceModel, operation string) (string, error) {
+	var template types.String
+	switch operation {
+	case "read":
+		template = data.ReadPath
+	case "update":
+		template = data.UpdatePath
+	case "delete":
+		template = data.DeletePath
+	}
+	if (template.IsNull() || template.IsUnknown()) && !data.SelfLink.IsNull() && !data.SelfLink.IsUnknown() {
+		return data.SelfLink.ValueString(), nil
+	}
+	if template.IsNull() || template.IsUnknown() {
+		template = data.ObjectPath
+	}
+
+	if template.IsNull() || template.IsUnknown() {
+		endpoint := data.Endpoint.ValueString()
+		if !data.Name.IsNull() {
+			return fmt.Sprintf("%s/%s", endpoint, escapePathSegments(data.Name.ValueString())), nil
+		}
+		if data.Id.IsNull() || data.Id.IsUnknown() || data.Id.ValueString() == "" {
+			return "", fmt.Errorf("cannot build the %s path: name is not set and the resource has no ID", operation)
+		}
+		return fmt.Sprintf("%s/%s", endpoint, url.PathEscape(data.Id.ValueString())), nil
+	}
+
+	return expandPathTemplate(template.ValueString(), r.pathTemplateValues(data))
+}
+
+// pathTemplateValues collects the placeholder values available to path templates:
+// top-level response fields, overridden by id, name, endpoint and self_link.
+func (r *RestResource) pathTemplateValues(data *RestResourceModel) map[string]string {
+	values := make(map[string]string)
+
+	for _, responseData := range []types.Map{data.ResponseData, data.SensitiveResponseData} {
+		if responseData.IsNull() || responseData.IsUnknown() {
+			continue
+		}
+		for key, element := range responseData.Elements() {
+			if value, ok := element.(types.String); ok && !value.IsNull() && !value.IsUnknown() {
+				values[key] = value.ValueString()
+			}
+		}
+	}
+
+	if !data.Id.IsNull() && !data.Id.IsUnknown() {
+		values["id"] = data.Id.ValueString()
+	}
+	if !data.Name.IsNull() && !data.Name.IsUnknown() {
+		values["name"] = data.Name.ValueString()
+	}
+	values["endpoint"] = data.Endpoint.ValueString()
+	if !data.SelfLink.IsNull() && !data.SelfLink.IsUnknown() {
+		values["self_link"] = data.SelfLink.ValueString()
+	}
+
+	return values
+}
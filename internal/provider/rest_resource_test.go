@@ -0,0 +1,490 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"terraform-provider-rest/internal/client"
+)
+
+func TestRestResource_ResponseID(t *testing.T) {
+	r := &RestResource{}
+
+	tests := []struct {
+		name         string
+		idAttribute  types.String
+		idFromHeader *IdFromHeaderModel
+		headers      map[string][]string
+		body         string
+		expected     string
+		found        bool
+		errMsg       string
+	}{
+		{
+			name:        "default string id",
+			idAttribute: types.StringNull(),
+			body:        `{"id": "item-1"}`,
+			expected:    "item-1",
+			found:       true,
+		},
+		{
+			name:        "default numeric id",
+			idAttribute: types.StringNull(),
+			body:        `{"id": 42}`,
+			expected:    "42",
+			found:       true,
+		},
+		{
+			name:        "default id missing falls back",
+			idAttribute: types.StringNull(),
+			body:        `{"name": "test"}`,
+			found:       false,
+		},
+		{
+			name:        "default id in non-JSON body falls back",
+			idAttribute: types.StringNull(),
+			body:        `created`,
+			found:       false,
+		},
+		{
+			name:        "nested id attribute",
+			idAttribute: types.StringValue("data.uuid"),
+			body:        `{"id": "outer", "data": {"uuid": "inner-uuid"}}`,
+			expected:    "inner-uuid",
+			found:       true,
+		},
+		{
+			name:        "json pointer id attribute",
+			idAttribute: types.StringValue("/data/0/id"),
+			body:        `{"data": [{"id": 7}]}`,
+			expected:    "7",
+			found:       true,
+		},
+		{
+			name:        "configured id attribute missing is an error",
+			idAttribute: types.StringValue("data.uuid"),
+			body:        `{"id": "outer"}`,
+			errMsg:      "no value at id_attribute",
+		},
+		{
+			name:        "configured id attribute with object value is an error",
+			idAttribute: types.StringValue("data"),
+			body:        `{"data": {"uuid": "x"}}`,
+			errMsg:      "must be a string, number or boolean",
+		},
+		{
+			name:         "header with capture group",
+			idAttribute:  types.StringNull(),
+			idFromHeader: &IdFromHeaderModel{Name: types.StringValue("Location"), Pattern: types.StringValue(`/items/([^/?]+)$`)},
+			headers:      map[string][]string{"Location": {"https://api.example.com/items/abc-9"}},
+			body:         `{"id": "ignored"}`,
+			expected:     "abc-9",
+			found:        true,
+		},
+		{
+			name:         "header without pattern",
+			idAttribute:  types.StringNull(),
+			idFromHeader: &IdFromHeaderModel{Name: types.StringValue("X-Resource-Id"), Pattern: types.StringNull()},
+			headers:      map[string][]string{"X-Resource-Id": {"res-5"}},
+			expected:     "res-5",
+			found:        true,
+		},
+		{
+			name:         "missing header is an error",
+			idAttribute:  types.StringNull(),
+			idFromHeader: &IdFromHeaderModel{Name: types.StringValue("Location"), Pattern: types.StringNull()},
+			errMsg:       "no Location header",
+		},
+		{
+			name:         "header not matching pattern is an error",
+			idAttribute:  types.StringNull(),
+			idFromHeader: &IdFromHeaderModel{Name: types.StringValue("Location"), Pattern: types.StringValue(`/items/(\d+)$`)},
+			headers:      map[string][]string{"Location": {"/items/abc"}},
+			errMsg:       "does not match",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := &RestResourceModel{
+				IdAttribute:  tt.idAttribute,
+				IdFromHeader: tt.idFromHeader,
+			}
+			response := &client.Response{
+				StatusCode: 201,
+				Body:       []byte(tt.body),
+				Headers:    tt.headers,
+			}
+
+			id, found, err := r.responseID(data, response)
+
+			if tt.errMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+					t.Errorf("Expected error containing %q, got %v", tt.errMsg, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if found != tt.found || id != tt.expected {
+				t.Errorf("Expected (%q, %v), got (%q, %v)", tt.expected, tt.found, id, found)
+			}
+		})
+	}
+}
+
+func TestRestResource_OperationPath(t *testing.T) {
+	r := &RestResource{}
+	responseData := types.MapValueMust(types.StringType, map[string]attr.Value{
+		"org": types.StringValue("acme"),
+	})
+
+	tests := []struct {
+		name      string
+		data      RestResourceModel
+		operation string
+		expected  string
+		expectErr bool
+	}{
+		{
+			name:      "default uses name",
+			data:      RestResourceModel{Endpoint: types.StringValue("/items"), Name: types.StringValue("my item"), Id: types.StringValue("42")},
+			operation: "read",
+			expected:  "/items/my%20item",
+		},
+		{
+			name:      "default uses id without name",
+			data:      RestResourceModel{Endpoint: types.StringValue("/items"), Name: types.StringNull(), Id: types.StringValue("42")},
+			operation: "delete",
+			expected:  "/items/42",
+		},
+		{
+			name:      "default without name or id",
+			data:      RestResourceModel{Endpoint: types.StringValue("/items"), Name: types.StringNull(), Id: types.StringUnknown()},
+			operation: "read",
+			expectErr: true,
+		},
+		{
+			name: "object path with response field",
+			data: RestResourceModel{
+				Endpoint:     types.StringValue("/items"),
+				Id:           types.StringValue("42"),
+				ResponseData: responseData,
+				ObjectPath:   types.StringValue("/orgs/{org}/items/{id}"),
+			},
+			operation: "read",
+			expected:  "/orgs/acme/items/42",
+		},
+		{
+			name: "operation path overrides object path",
+			data: RestResourceModel{
+				Endpoint:   types.StringValue("/items"),
+				Id:         types.StringValue("42"),
+				ObjectPath: types.StringValue("/items/{id}"),
+				UpdatePath: types.StringValue("/items/{id}:update"),
+			},
+			operation: "update",
+			expected:  "/items/42:update",
+		},
+		{
+			name: "object path used for other operations",
+			data: RestResourceModel{
+				Endpoint:   types.StringValue("/items"),
+				Id:         types.StringValue("42"),
+				ObjectPath: types.StringValue("/items/{id}"),
+				UpdatePath: types.StringValue("/items/{id}:update"),
+			},
+			operation: "delete",
+			expected:  "/items/42",
+		},
+		{
+			name: "self link overrides object path",
+			data: RestResourceModel{
+				Endpoint:   types.StringValue("/items"),
+				Name:       types.StringValue("widget"),
+				ObjectPath: types.StringValue("/items/{name}"),
+				SelfLink:   types.StringValue("https://api.example.com/items/42"),
+			},
+			operation: "read",
+			expected:  "https://api.example.com/items/42",
+		},
+		{
+			name: "operation path overrides self link",
+			data: RestResourceModel{
+				Endpoint:   types.StringValue("/items"),
+				SelfLink:   types.StringValue("https://api.example.com/items/42"),
+				UpdatePath: types.StringValue("{self_link}:update"),
+			},
+			operation: "update",
+			expected:  "https://api.example.com/items/42:update",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := r.operationPath(&tt.data, tt.operation)
+
+			if tt.expectErr {
+				if err == nil {
+					t.Errorf("Expected error, got %q", result)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if result != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestRestResource_PerformDriftDetection(t *testing.T) {
+	r := &RestResource{}
+	body := `{"name":"widget","size":3,"labels":{"team":"a","updated_at":"x"},"tags":["a","b"]}`
+
+	tests := []struct {
+		name          string
+		action        types.String
+		ignoreMissing bool
+		response      string
+		expectedBody  string
+		warnings      int
+		errors        int
+	}{
+		{
+			name:         "no drift leaves body untouched",
+			action:       types.StringNull(),
+			response:     `{"id":"1","name":"widget","size":3,"labels":{"team":"a","updated_at":"y"},"tags":["a","b"],"extra":true}`,
+			expectedBody: body,
+		},
+		{
+			name:         "reconcile writes the live projection",
+			action:       types.StringNull(),
+			response:     `{"id":"1","name":"gadget","size":3,"labels":{"team":"b","updated_at":"y"},"tags":["a"],"extra":true}`,
+			expectedBody: `{"labels":{"team":"b","updated_at":"x"},"name":"gadget","size":3,"tags":["a"]}`,
+		},
+		{
+			name:         "reconcile drops missing keys",
+			action:       types.StringValue("reconcile"),
+			response:     `{"name":"widget","labels":{"team":"a"},"tags":["a","b"]}`,
+			expectedBody: `{"labels":{"team":"a","updated_at":"x"},"name":"widget","tags":["a","b"]}`,
+		},
+		{
+			name:          "keys the API does not return are skipped when asked",
+			action:        types.StringValue("reconcile"),
+			ignoreMissing: true,
+			response:      `{"name":"widget","labels":{"team":"a"},"tags":["a","b"]}`,
+			expectedBody:  body,
+		},
+		{
+			name:          "reconcile keeps keys the API does not return when asked",
+			action:        types.StringValue("reconcile"),
+			ignoreMissing: true,
+			response:      `{"name":"gadget","labels":{},"tags":["a","b"]}`,
+			expectedBody:  `{"labels":{"team":"a","updated_at":"x"},"name":"gadget","size":3,"tags":["a","b"]}`,
+		},
+		{
+			name:          "warn ignores keys the API does not return when asked",
+			action:        types.StringValue("warn"),
+			ignoreMissing: true,
+			response:      `{"name":"widget","labels":{"team":"a"},"tags":["a","b"]}`,
+			expectedBody:  body,
+		},
+		{
+			name:         "warn keeps body",
+			action:       types.StringValue("warn"),
+			response:     `{"name":"gadget","size":3,"labels":{"team":"a"},"tags":["a","b"]}`,
+			expectedBody: body,
+			warnings:     1,
+		},
+		{
+			name:         "error fails",
+			action:       types.StringValue("error"),
+			response:     `{"name":"gadget","size":3,"labels":{"team":"a"},"tags":["a","b"]}`,
+			expectedBody: body,
+			errors:       1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := &RestResourceModel{
+				Id:                     types.StringValue("1"),
+				Endpoint:               types.StringValue("/items"),
+				Body:                   NewJSONBodyValue(body),
+				DriftAction:            tt.action,
+				DriftIgnoreMissingKeys: types.BoolValue(tt.ignoreMissing),
+				DriftDetection:         types.BoolNull(),
+				IgnoreFields:           types.ListNull(types.StringType),
+			}
+			var diags diag.Diagnostics
+
+			err := r.performDriftDetection(context.Background(), data, &client.Response{StatusCode: 200, Body: []byte(tt.response)}, &diags)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			if data.Body.ValueString() != tt.expectedBody {
+				t.Errorf("Expected body %s, got %s", tt.expectedBody, data.Body.ValueString())
+			}
+			if diags.WarningsCount() != tt.warnings || diags.ErrorsCount() != tt.errors {
+				t.Errorf("Expected %d warnings and %d errors, got %v", tt.warnings, tt.errors, diags)
+			}
+		})
+	}
+}
+
+func TestRestResource_UpgradeStateFromVersion0(t *testing.T) {
+	ctx := context.Background()
+	r := &RestResource{}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	if schemaResp.Schema.Version != 1 {
+		t.Fatalf("Expected schema version 1, got %d", schemaResp.Schema.Version)
+	}
+
+	upgrader, ok := r.UpgradeState(ctx)[0]
+	if !ok {
+		t.Fatalf("Expected an upgrader for version 0")
+	}
+
+	// A version 0 state with formatted JSON, a non-JSON body and an attribute
+	// that no longer exists
+	rawState := &tfprotov6.RawState{JSON: []byte(`{
+		"id": "item-1",
+		"endpoint": "/items",
+		"name": "widget",
+		"body": "{\n  \"name\": \"widget\"\n}",
+		"destroy_body": "force=true",
+		"status_code": 200,
+		"removed_attribute": "x"
+	}`)}
+
+	resp := &resource.UpgradeStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	upgrader.StateUpgrader(ctx, resource.UpgradeStateRequest{RawState: rawState}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var data RestResourceModel
+	if diags := resp.State.Get(ctx, &data); diags.HasError() {
+		t.Fatalf("Failed to read upgraded state: %v", diags)
+	}
+
+	if data.Body.ValueString() != "{\n  \"name\": \"widget\"\n}" {
+		t.Errorf("Expected body text to be kept, got %q", data.Body.ValueString())
+	}
+	if data.DestroyBody.ValueString() != "force=true" {
+		t.Errorf("Expected destroy_body to be kept, got %q", data.DestroyBody.ValueString())
+	}
+	if data.Id.ValueString() != "item-1" || data.StatusCode.ValueInt64() != 200 {
+		t.Errorf("Expected id and status_code to be kept, got %q and %d", data.Id.ValueString(), data.StatusCode.ValueInt64())
+	}
+	if !data.UpdateBody.IsNull() || !data.DriftReport.IsNull() {
+		t.Errorf("Expected attributes missing from version 0 to be null")
+	}
+}
+
+func TestRestResource_ProcessResponseRedaction(t *testing.T) {
+	ctx := context.Background()
+	r := &RestResource{}
+	response := &client.Response{
+		StatusCode: 201,
+		Body:       []byte(`{"id":"item-1","name":"widget","credentials":{"user":"u","secret":"s"}}`),
+	}
+
+	tests := []struct {
+		name      string
+		redact    []attr.Value
+		sensitive types.Bool
+		body      string
+		data      map[string]string
+	}{
+		{
+			name:      "no redaction",
+			sensitive: types.BoolNull(),
+			body:      `{"id":"item-1","name":"widget","credentials":{"user":"u","secret":"s"}}`,
+			data:      map[string]string{"id": "item-1", "name": "widget", "credentials": `{"secret":"s","user":"u"}`},
+		},
+		{
+			name:      "redacted paths are removed",
+			redact:    []attr.Value{types.StringValue("credentials.secret")},
+			sensitive: types.BoolNull(),
+			body:      `{"credentials":{"user":"u"},"id":"item-1","name":"widget"}`,
+			data:      map[string]string{"id": "item-1", "name": "widget", "credentials": `{"user":"u"}`},
+		},
+		{
+			name:      "sensitive response moves to sensitive attributes",
+			redact:    []attr.Value{types.StringValue("credentials")},
+			sensitive: types.BoolValue(true),
+			body:      `{"id":"item-1","name":"widget"}`,
+			data:      map[string]string{"id": "item-1", "name": "widget"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			redact := types.ListNull(types.StringType)
+			if tt.redact != nil {
+				redact = types.ListValueMust(types.StringType, tt.redact)
+			}
+			data := &RestResourceModel{
+				Id:                  types.StringNull(),
+				Endpoint:            types.StringValue("/items"),
+				RedactResponsePaths: redact,
+				SensitiveResponse:   tt.sensitive,
+			}
+
+			if err := r.processResponse(ctx, response, data); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			// The ID comes from the raw response even when it is redacted
+			if data.Id.ValueString() != "item-1" {
+				t.Errorf("Expected id item-1, got %s", data.Id.ValueString())
+			}
+
+			body, responseData := data.Response, data.ResponseData
+			hidden, hiddenData := data.SensitiveResponseBody, data.SensitiveResponseData
+			if tt.sensitive.ValueBool() {
+				body, hidden = hidden, body
+				responseData, hiddenData = hiddenData, responseData
+			}
+			if !hidden.IsNull() || !hiddenData.IsNull() {
+				t.Errorf("Expected the unused response attributes to be null")
+			}
+
+			if body.ValueString() != tt.body {
+				t.Errorf("Expected body %s, got %s", tt.body, body.ValueString())
+			}
+			if data.ResponseObject.IsNull() != tt.sensitive.ValueBool() {
+				t.Errorf("Expected response_object to be null only for sensitive responses, got %s", data.ResponseObject)
+			}
+			if object, ok := data.ResponseObject.UnderlyingValue().(types.Object); ok && len(object.Attributes()) != len(tt.data) {
+				t.Errorf("Expected response_object to match the redacted body, got %s", object)
+			}
+			elements := responseData.Elements()
+			if len(elements) != len(tt.data) {
+				t.Fatalf("Expected response data %v, got %v", tt.data, elements)
+			}
+			for key, expected := range tt.data {
+				if value, ok := elements[key].(types.String); !ok || value.ValueString() != expected {
+					t.Errorf("Expected response_data[%s] = %s, got %v", key, expected, elements[key])
+				}
+			}
+		})
+	}
+}
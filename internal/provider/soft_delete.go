@@ -0,0 +1,188 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"terraform-provider-rest/internal/client"
+)
+
+const (
+	deleteModeHard          = "hard"
+	deleteModeSoftThenPurge = "soft_then_purge"
+)
+
+// GoneWhenModel describes read responses that mean the object no longer exists
+// even though the API answered with a success status.
+type GoneWhenModel struct {
+	Path        types.String   `tfsdk:"path"`
+	Values      []types.String `tfsdk:"values"`
+	BodyPattern types.String   `tfsdk:"body_pattern"`
+}
+
+// goneWhenAttribute returns the schema for the gone_when block
+func goneWhenAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "Treat a successful read as \"object gone\" when its body matches, for APIs that answer `200` with `{\"deleted\": true}` or an archived status instead of `404`. The object is then removed from state and planned for creation. Applies to refresh, `wait_for_delete` and `check_exists_before_create`.",
+		Optional:            true,
+		Attributes: map[string]schema.Attribute{
+			"path": schema.StringAttribute{
+				MarkdownDescription: "JSON path or pointer to check in the read response, e.g. `deleted` or `$.status`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("values")),
+					stringvalidator.AtLeastOneOf(path.MatchRelative().AtParent().AtName("body_pattern")),
+				},
+			},
+			"values": schema.ListAttribute{
+				MarkdownDescription: "Values at `path` that mean the object is gone, e.g. `[\"true\"]` or `[\"ARCHIVED\", \"DELETED\"]`.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.List{
+					listvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("path")),
+				},
+			},
+			"body_pattern": schema.StringAttribute{
+				MarkdownDescription: "A regular expression; a response body that matches it means the object is gone.",
+				Optional:            true,
+				Validators: []validator.String{
+					validRegex(),
+				},
+			},
+		},
+	}
+}
+
+// deleteMode returns the configured delete_mode
+func deleteMode(data *RestResourceModel) string {
+	if data.DeleteMode.IsNull() || data.DeleteMode.IsUnknown() {
+		return deleteModeHard
+	}
+	return data.DeleteMode.ValueString()
+}
+
+// isGoneResponse reports whether a successful read response matches gone_when.
+// The returned state describes the match for logging.
+func isGoneResponse(data *RestResourceModel, response *client.Response) (bool, string, error) {
+	gone := data.GoneWhen
+	if gone == nil {
+		return false, "", nil
+	}
+
+	if !gone.Path.IsNull() && !gone.Path.IsUnknown() {
+		value, found, err := responseValue(response, gone.Path.ValueString())
+		if err != nil {
+			return false, "", fmt.Errorf("invalid gone_when path: %w", err)
+		}
+		if found && stringSet(gone.Values)[value] {
+			return true, value, nil
+		}
+	}
+
+	if !gone.BodyPattern.IsNull() && !gone.BodyPattern.IsUnknown() {
+		pattern, err := regexp.Compile(gone.BodyPattern.ValueString())
+		if err != nil {
+			return false, "", fmt.Errorf("invalid gone_when body_pattern: %w", err)
+		}
+		if pattern.Match(response.Body) {
+			return true, "body_pattern matched", nil
+		}
+	}
+
+	return false, "", nil
+}
+
+// readGone combines the read status policy decision and gone_when into the
+// outcome of a read response. A failure that on_failure continues past counts
+// as a success.
+func (r *RestResource) readGone(ctx context.Context, data *RestResourceModel, response *client.Response, decision statusDecision) (statusOutcome, error) {
+	if decision.outcome == statusGone {
+		return statusGone, nil
+	}
+	if checkFailedStatus(ctx, response, decision) != nil {
+		return statusFailed, nil
+	}
+
+	gone, _, err := isGoneResponse(data, response)
+	if err != nil {
+		return statusFailed, err
+	}
+	if gone {
+		return statusGone, nil
+	}
+	return statusSucceeded, nil
+}
+
+// purgeObject sends the purge request that removes a soft-deleted object for
+// good. It is sent to purge_path, or the delete path when that is not set, and
+// checked against the delete status policy, so an object that is already gone
+// counts as purged.
+func (r *RestResource) purgeObject(ctx context.Context, data *RestResourceModel, diags *diag.Diagnostics) {
+	var endpoint string
+	var err error
+	if !data.PurgePath.IsNull() && !data.PurgePath.IsUnknown() {
+		endpoint, err = expandPathTemplate(data.PurgePath.ValueString(), r.pathTemplateValues(data))
+	} else {
+		endpoint, err = r.operationPath(data, "delete")
+	}
+	if err != nil {
+		diags.AddError("Invalid Object Path", err.Error())
+		return
+	}
+
+	method := "DELETE"
+	if !data.PurgeMethod.IsNull() && !data.PurgeMethod.IsUnknown() {
+		method = data.PurgeMethod.ValueString()
+	}
+
+	options := r.buildRequestOptions(ctx, data, method, "")
+	options.Endpoint = endpoint
+
+	tflog.Trace(ctx, "purging REST resource", map[string]interface{}{
+		"endpoint": endpoint,
+		"id":       data.Id.ValueString(),
+	})
+
+	policy, err := r.statusPolicy(ctx, data, "delete")
+	if err != nil {
+		diags.AddError("Invalid Status Policy", err.Error())
+		return
+	}
+
+	response, decision, err := doWithStatusPolicy(ctx, r.client, options, policy)
+	if err != nil {
+		addRequestError(diags, err, method, endpoint)
+		return
+	}
+
+	switch decision.outcome {
+	case statusGone:
+		tflog.Debug(ctx, "object already purged", map[string]interface{}{
+			"endpoint":    endpoint,
+			"status_code": response.StatusCode,
+		})
+		return
+	case statusFailed:
+		if err := checkFailedStatus(ctx, response, decision); err != nil {
+			diags.AddError("Purge Failed", err.Error())
+			return
+		}
+	}
+
+	if !decision.stop() && r.shouldPoll(data, response) {
+		if err := r.completeOperation(ctx, data, response, false); err != nil {
+			diags.AddError("Operation Failed", err.Error())
+		}
+	}
+}
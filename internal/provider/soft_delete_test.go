@@ -0,0 +1,169 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-rest/internal/client"
+)
+
+func TestIsGoneResponse(t *testing.T) {
+	tests := []struct {
+		name     string
+		goneWhen *GoneWhenModel
+		body     string
+		gone     bool
+		errMsg   string
+	}{
+		{
+			name: "no condition",
+			body: `{"deleted": true}`,
+		},
+		{
+			name:     "boolean field",
+			goneWhen: &GoneWhenModel{Path: types.StringValue("deleted"), Values: statusValues("true"), BodyPattern: types.StringNull()},
+			body:     `{"id": "42", "deleted": true}`,
+			gone:     true,
+		},
+		{
+			name:     "status value not listed",
+			goneWhen: &GoneWhenModel{Path: types.StringValue("$.status"), Values: statusValues("ARCHIVED", "DELETED"), BodyPattern: types.StringNull()},
+			body:     `{"status": "ACTIVE"}`,
+		},
+		{
+			name:     "missing field",
+			goneWhen: &GoneWhenModel{Path: types.StringValue("/metadata/status"), Values: statusValues("ARCHIVED"), BodyPattern: types.StringNull()},
+			body:     `{"status": "ARCHIVED"}`,
+		},
+		{
+			name:     "body pattern",
+			goneWhen: &GoneWhenModel{Path: types.StringNull(), BodyPattern: types.StringValue(`"state":\s*"(ARCHIVED|PURGED)"`)},
+			body:     `{"state": "ARCHIVED"}`,
+			gone:     true,
+		},
+		{
+			name:     "body pattern on a non-JSON body",
+			goneWhen: &GoneWhenModel{Path: types.StringNull(), BodyPattern: types.StringValue(`^object deleted`)},
+			body:     `object deleted at 2024-01-01`,
+			gone:     true,
+		},
+		{
+			name:     "invalid body pattern",
+			goneWhen: &GoneWhenModel{Path: types.StringNull(), BodyPattern: types.StringValue(`(`)},
+			body:     `{}`,
+			errMsg:   "invalid gone_when body_pattern",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := &RestResourceModel{GoneWhen: tt.goneWhen}
+			gone, _, err := isGoneResponse(data, &client.Response{StatusCode: 200, Body: []byte(tt.body)})
+
+			if tt.errMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+					t.Errorf("Expected error containing %q, got %v", tt.errMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if gone != tt.gone {
+				t.Errorf("Expected gone=%v, got %v", tt.gone, gone)
+			}
+		})
+	}
+}
+
+func TestRestResource_PurgeObject(t *testing.T) {
+	tests := []struct {
+		name        string
+		purgePath   types.String
+		purgeMethod types.String
+		status      int
+		expectURL   string
+		expectVerb  string
+		errMsg      string
+	}{
+		{
+			name:        "delete path by default",
+			purgePath:   types.StringNull(),
+			purgeMethod: types.StringNull(),
+			status:      http.StatusNoContent,
+			expectURL:   "/items/42",
+			expectVerb:  "DELETE",
+		},
+		{
+			name:        "purge path template with query",
+			purgePath:   types.StringValue("/trash/{id}?permanent=true"),
+			purgeMethod: types.StringValue("POST"),
+			status:      http.StatusOK,
+			expectURL:   "/trash/42?permanent=true",
+			expectVerb:  "POST",
+		},
+		{
+			name:        "already purged",
+			purgePath:   types.StringNull(),
+			purgeMethod: types.StringNull(),
+			status:      http.StatusNotFound,
+			expectURL:   "/items/42",
+			expectVerb:  "DELETE",
+		},
+		{
+			name:        "purge rejected",
+			purgePath:   types.StringNull(),
+			purgeMethod: types.StringNull(),
+			status:      http.StatusForbidden,
+			expectURL:   "/items/42",
+			expectVerb:  "DELETE",
+			errMsg:      "403",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requestURL, method string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requestURL = r.URL.RequestURI()
+				method = r.Method
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			restClient, err := client.NewRestClient(client.Config{BaseURL: server.URL})
+			if err != nil {
+				t.Fatalf("Failed to create client: %s", err)
+			}
+			r := &RestResource{client: restClient}
+			data := &RestResourceModel{
+				Endpoint:    types.StringValue("/items"),
+				Id:          types.StringValue("42"),
+				DeleteMode:  types.StringValue(deleteModeSoftThenPurge),
+				PurgePath:   tt.purgePath,
+				PurgeMethod: tt.purgeMethod,
+			}
+			var diags diag.Diagnostics
+
+			r.purgeObject(context.Background(), data, &diags)
+
+			if requestURL != tt.expectURL || method != tt.expectVerb {
+				t.Errorf("Expected %s %s, got %s %s", tt.expectVerb, tt.expectURL, method, requestURL)
+			}
+			if tt.errMsg != "" {
+				if !diags.HasError() || !strings.Contains(diags.Errors()[0].Detail(), tt.errMsg) {
+					t.Errorf("Expected error containing %q, got %v", tt.errMsg, diags)
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Errorf("Unexpected errors: %v", diags)
+			}
+		})
+	}
+}
@@ -0,0 +1,451 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	dschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"terraform-provider-rest/internal/client"
+)
+
+const (
+	defaultStatusMaxRetries    = 5
+	defaultStatusRetryInterval = 5 * time.Second
+)
+
+// statusPattern matches a status code ("404"), a class ("2xx") or a range
+// ("200-204")
+var statusPattern = regexp.MustCompile(`^([1-5]xx|[1-5][0-9]{2}(-[1-5][0-9]{2})?)$`)
+
+// StatusPoliciesModel holds a status policy per CRUD operation.
+type StatusPoliciesModel struct {
+	Create *StatusPolicyModel `tfsdk:"create"`
+	Read   *StatusPolicyModel `tfsdk:"read"`
+	Update *StatusPolicyModel `tfsdk:"update"`
+	Delete *StatusPolicyModel `tfsdk:"delete"`
+}
+
+// StatusPolicyModel describes how the response status of one operation is
+// interpreted.
+type StatusPolicyModel struct {
+	Success       []types.String `tfsdk:"success"`
+	Fail          []types.String `tfsdk:"fail"`
+	Gone          []types.String `tfsdk:"gone"`
+	Retry         []types.String `tfsdk:"retry"`
+	OnSuccess     types.String   `tfsdk:"on_success"`
+	OnFailure     types.String   `tfsdk:"on_failure"`
+	MaxRetries    types.Int64    `tfsdk:"max_retries"`
+	RetryInterval types.Int64    `tfsdk:"retry_interval"`
+}
+
+// statusPolicyAttribute returns the schema for the status_policy block
+func statusPolicyAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "How response status codes are interpreted, per operation. Statuses are given as codes (`404`), classes (`2xx`) or ranges (`200-204`).",
+		Optional:            true,
+		Attributes: map[string]schema.Attribute{
+			"create": operationStatusPolicyAttribute("create", "Overrides `expected_status`, `fail_on_status`, `retry_on_status`, `on_success` and `on_failure`."),
+			"read":   operationStatusPolicyAttribute("read", "A `gone` status removes the object from state so it is planned for creation."),
+			"update": operationStatusPolicyAttribute("update", "A `gone` status fails with a hint to refresh and recreate the object."),
+			"delete": operationStatusPolicyAttribute("delete", "A `gone` status means the object was already deleted."),
+		},
+	}
+}
+
+func operationStatusPolicyAttribute(operation, goneNote string) schema.SingleNestedAttribute {
+	statuses := []validator.List{
+		listvalidator.ValueStringsAre(stringvalidator.RegexMatches(statusPattern, "must be a status code like 404, a class like 2xx or a range like 200-204")),
+	}
+
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: fmt.Sprintf("Status policy for %s requests. %s", operation, goneNote),
+		Optional:            true,
+		Attributes: map[string]schema.Attribute{
+			"success": schema.ListAttribute{
+				MarkdownDescription: "Statuses that mean success; any other status is a failure. Default: `[\"2xx\"]`.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators:          statuses,
+			},
+			"fail": schema.ListAttribute{
+				MarkdownDescription: "Statuses that are failures even if `success` includes them.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators:          statuses,
+			},
+			"gone": schema.ListAttribute{
+				MarkdownDescription: "Statuses that mean the object does not exist, e.g. `410`, or `403` for APIs that hide objects the caller cannot see. Default: `[\"404\", \"410\"]` for read, update and delete; not used for create.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators:          statuses,
+			},
+			"retry": schema.ListAttribute{
+				MarkdownDescription: "Statuses that send the request again, e.g. `423` or `409` while the API is busy, up to `max_retries` times.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators:          statuses,
+			},
+			"on_success": schema.StringAttribute{
+				MarkdownDescription: "What to do after a success: `continue` (default); `stop` to record the response and skip polling and waiting; or `retry` to send the request again while the API answers `202 Accepted`, until it returns another success status.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("continue", "stop", "retry"),
+				},
+			},
+			"on_failure": schema.StringAttribute{
+				MarkdownDescription: "What to do after a failure: `fail` (default); `continue` to log a warning and treat the response as a success; or `retry` to send the request again until it succeeds or `max_retries` is used up.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("fail", "continue", "retry"),
+				},
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: "How many times a request is sent again by `retry`, `on_success = \"retry\"` or `on_failure = \"retry\"`. Default: 5.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"retry_interval": schema.Int64Attribute{
+				MarkdownDescription: "Seconds to wait before sending the request again. A `Retry-After` header takes precedence. Default: 5.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+		},
+	}
+}
+
+// dataSourceStatusPolicyAttribute builds the status policy attribute for data
+// source schemas from the resource one, so the settings stay the same
+func dataSourceStatusPolicyAttribute(operation, goneNote string) dschema.SingleNestedAttribute {
+	resourceAttribute := operationStatusPolicyAttribute(operation, goneNote)
+
+	attributes := make(map[string]dschema.Attribute, len(resourceAttribute.Attributes))
+	for name, attribute := range resourceAttribute.Attributes {
+		switch a := attribute.(type) {
+		case schema.ListAttribute:
+			attributes[name] = dschema.ListAttribute{MarkdownDescription: a.MarkdownDescription, ElementType: a.ElementType, Optional: true, Validators: a.Validators}
+		case schema.StringAttribute:
+			attributes[name] = dschema.StringAttribute{MarkdownDescription: a.MarkdownDescription, Optional: true, Validators: a.Validators}
+		case schema.Int64Attribute:
+			attributes[name] = dschema.Int64Attribute{MarkdownDescription: a.MarkdownDescription, Optional: true, Validators: a.Validators}
+		}
+	}
+
+	return dschema.SingleNestedAttribute{
+		MarkdownDescription: resourceAttribute.MarkdownDescription,
+		Optional:            true,
+		Attributes:          attributes,
+	}
+}
+
+// statusOutcome is what a response status means for an operation
+type statusOutcome int
+
+const (
+	statusSucceeded statusOutcome = iota
+	statusFailed
+	statusGone
+)
+
+// statusDecision is the evaluated meaning of a response status
+type statusDecision struct {
+	outcome statusOutcome
+	// action is on_success for successes and on_failure for failures
+	action string
+	// retry asks for the request to be sent again
+	retry bool
+}
+
+// stop reports whether processing should end after recording the response
+func (d statusDecision) stop() bool {
+	return d.outcome == statusSucceeded && d.action == "stop"
+}
+
+// statusRange is an inclusive range of status codes
+type statusRange struct {
+	low, high int
+}
+
+// statusPolicy is the evaluated form of a StatusPolicyModel
+type statusPolicy struct {
+	success       []statusRange
+	fail          []statusRange
+	gone          []statusRange
+	retry         []statusRange
+	onSuccess     string
+	onFailure     string
+	maxRetries    int
+	retryInterval time.Duration
+}
+
+// statusPolicy returns the status policy for an operation. The create policy
+// falls back to the top-level expected_status, fail_on_status, retry_on_status,
+// on_success and on_failure attributes.
+func (r *RestResource) statusPolicy(ctx context.Context, data *RestResourceModel, operation string) (*statusPolicy, error) {
+	var model *StatusPolicyModel
+	if data.StatusPolicy != nil {
+		switch operation {
+		case "create":
+			model = data.StatusPolicy.Create
+		case "read":
+			model = data.StatusPolicy.Read
+		case "update":
+			model = data.StatusPolicy.Update
+		case "delete":
+			model = data.StatusPolicy.Delete
+		}
+	}
+
+	if model == nil {
+		policy := defaultStatusPolicy(operation)
+		if operation == "create" {
+			return policy, r.applyLegacyStatusSettings(ctx, data, policy)
+		}
+		return policy, nil
+	}
+	return newStatusPolicy(operation, model)
+}
+
+// newStatusPolicy applies a StatusPolicyModel over the defaults for an
+// operation
+func newStatusPolicy(operation string, model *StatusPolicyModel) (*statusPolicy, error) {
+	policy := defaultStatusPolicy(operation)
+	if model == nil {
+		return policy, nil
+	}
+
+	var err error
+	if model.Success != nil {
+		if policy.success, err = parseStatusRanges(model.Success); err != nil {
+			return nil, err
+		}
+	}
+	if policy.fail, err = parseStatusRanges(model.Fail); err != nil {
+		return nil, err
+	}
+	if model.Gone != nil && operation != "create" {
+		if policy.gone, err = parseStatusRanges(model.Gone); err != nil {
+			return nil, err
+		}
+	}
+	if policy.retry, err = parseStatusRanges(model.Retry); err != nil {
+		return nil, err
+	}
+	if !model.OnSuccess.IsNull() {
+		policy.onSuccess = model.OnSuccess.ValueString()
+	}
+	if !model.OnFailure.IsNull() {
+		policy.onFailure = model.OnFailure.ValueString()
+	}
+	if !model.MaxRetries.IsNull() {
+		policy.maxRetries = int(model.MaxRetries.ValueInt64())
+	}
+	if !model.RetryInterval.IsNull() {
+		policy.retryInterval = time.Duration(model.RetryInterval.ValueInt64()) * time.Second
+	}
+	return policy, nil
+}
+
+// defaultStatusPolicy accepts 2xx and, except for create, treats 404 and 410 as
+// gone
+func defaultStatusPolicy(operation string) *statusPolicy {
+	policy := &statusPolicy{
+		success:       []statusRange{{200, 299}},
+		onSuccess:     "continue",
+		onFailure:     "fail",
+		maxRetries:    defaultStatusMaxRetries,
+		retryInterval: defaultStatusRetryInterval,
+	}
+	if operation != "create" {
+		policy.gone = []statusRange{{http.StatusNotFound, http.StatusNotFound}, {http.StatusGone, http.StatusGone}}
+	}
+	return policy
+}
+
+// applyLegacyStatusSettings applies the top-level status attributes, which
+// predate status_policy and only ever applied to create
+func (r *RestResource) applyLegacyStatusSettings(ctx context.Context, data *RestResourceModel, policy *statusPolicy) error {
+	lists := []struct {
+		name   string
+		value  types.List
+		target *[]statusRange
+	}{
+		{"expected_status", data.ExpectedStatus, &policy.success},
+		{"fail_on_status", data.FailOnStatus, &policy.fail},
+		{"retry_on_status", data.RetryOnStatus, &policy.retry},
+	}
+	for _, list := range lists {
+		if list.value.IsNull() || list.value.IsUnknown() {
+			continue
+		}
+		var codes []int64
+		if diags := list.value.ElementsAs(ctx, &codes, false); diags.HasError() {
+			return fmt.Errorf("failed to read %s: %v", list.name, diags.Errors())
+		}
+		ranges := make([]statusRange, 0, len(codes))
+		for _, code := range codes {
+			ranges = append(ranges, statusRange{int(code), int(code)})
+		}
+		*list.target = ranges
+	}
+
+	if !data.OnSuccess.IsNull() && !data.OnSuccess.IsUnknown() {
+		policy.onSuccess = data.OnSuccess.ValueString()
+	}
+	if !data.OnFailure.IsNull() && !data.OnFailure.IsUnknown() {
+		policy.onFailure = data.OnFailure.ValueString()
+	}
+	return nil
+}
+
+// parseStatusRanges parses codes ("404"), classes ("2xx") and ranges ("200-204")
+func parseStatusRanges(values []types.String) ([]statusRange, error) {
+	ranges := make([]statusRange, 0, len(values))
+	for _, value := range values {
+		if value.IsNull() || value.IsUnknown() {
+			continue
+		}
+		text := strings.TrimSpace(value.ValueString())
+		if !statusPattern.MatchString(text) {
+			return nil, fmt.Errorf("invalid status %q: use a code like 404, a class like 2xx or a range like 200-204", text)
+		}
+
+		if strings.HasSuffix(text, "xx") {
+			class := int(text[0]-'0') * 100
+			ranges = append(ranges, statusRange{class, class + 99})
+			continue
+		}
+		low, high, isRange := strings.Cut(text, "-")
+		lowCode, _ := strconv.Atoi(low)
+		highCode := lowCode
+		if isRange {
+			highCode, _ = strconv.Atoi(high)
+		}
+		if highCode < lowCode {
+			return nil, fmt.Errorf("invalid status range %q: the end is before the start", text)
+		}
+		ranges = append(ranges, statusRange{lowCode, highCode})
+	}
+	return ranges, nil
+}
+
+// statusIn reports whether status falls in any of ranges
+func statusIn(status int, ranges []statusRange) bool {
+	for _, statusRange := range ranges {
+		if status >= statusRange.low && status <= statusRange.high {
+			return true
+		}
+	}
+	return false
+}
+
+// evaluate interprets a response status. fail takes precedence over gone, which
+// takes precedence over success.
+func (p *statusPolicy) evaluate(status int) statusDecision {
+	var decision statusDecision
+	switch {
+	case statusIn(status, p.fail):
+		decision = statusDecision{outcome: statusFailed, action: p.onFailure}
+	case statusIn(status, p.gone):
+		decision = statusDecision{outcome: statusGone}
+	case statusIn(status, p.success):
+		decision = statusDecision{outcome: statusSucceeded, action: p.onSuccess}
+	default:
+		decision = statusDecision{outcome: statusFailed, action: p.onFailure}
+	}
+
+	decision.retry = statusIn(status, p.retry) ||
+		(decision.outcome == statusFailed && decision.action == "retry") ||
+		(decision.outcome == statusSucceeded && decision.action == "retry" && status == http.StatusAccepted)
+	return decision
+}
+
+// doWithStatusPolicy sends a request and evaluates its status, sending it again
+// while the policy asks for a retry and retries remain. The decision for the
+// last response is returned; retries that run out leave the request failed.
+func doWithStatusPolicy(ctx context.Context, restClient *client.RestClient, options client.RequestOptions, policy *statusPolicy) (*client.Response, statusDecision, error) {
+	for attempt := 0; ; attempt++ {
+		response, err := restClient.Do(ctx, options)
+		if err != nil {
+			return nil, statusDecision{}, err
+		}
+
+		decision := policy.evaluate(response.StatusCode)
+		if !decision.retry {
+			return response, decision, nil
+		}
+		if attempt >= policy.maxRetries {
+			tflog.Warn(ctx, "giving up retrying request", map[string]interface{}{
+				"method":      options.Method,
+				"endpoint":    options.Endpoint,
+				"status_code": response.StatusCode,
+				"retries":     attempt,
+			})
+			decision.retry = false
+			if decision.outcome != statusGone {
+				decision.outcome = statusFailed
+				decision.action = "fail"
+			}
+			return response, decision, nil
+		}
+
+		wait := policy.retryInterval
+		if retryAfter, ok := parseRetryAfter(http.Header(response.Headers).Get("Retry-After")); ok {
+			wait = retryAfter
+		}
+		tflog.Debug(ctx, "retrying request for status policy", map[string]interface{}{
+			"method":      options.Method,
+			"endpoint":    options.Endpoint,
+			"status_code": response.StatusCode,
+			"attempt":     attempt + 1,
+			"wait":        wait.String(),
+		})
+
+		select {
+		case <-ctx.Done():
+			return nil, statusDecision{}, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// checkFailedStatus applies on_failure to a failed decision. It returns an
+// error describing the response unless on_failure is "continue", in which case
+// a warning is logged and the response is treated as a success. Other outcomes
+// are left to the caller.
+func checkFailedStatus(ctx context.Context, response *client.Response, decision statusDecision) error {
+	if decision.outcome != statusFailed {
+		return nil
+	}
+	if decision.action != "continue" {
+		return errors.New(statusError(response))
+	}
+	tflog.Warn(ctx, "continuing despite failed status code", map[string]interface{}{
+		"status_code": response.StatusCode,
+		"response":    string(response.Body),
+	})
+	return nil
+}
+
+// statusError describes a response that failed its status policy
+func statusError(response *client.Response) string {
+	return fmt.Sprintf("Received non-success response code: %d, Response: %s", response.StatusCode, string(response.Body))
+}
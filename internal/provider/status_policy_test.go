@@ -0,0 +1,266 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-rest/internal/client"
+)
+
+func statusValues(values ...string) []types.String {
+	result := make([]types.String, 0, len(values))
+	for _, value := range values {
+		result = append(result, types.StringValue(value))
+	}
+	return result
+}
+
+func TestParseStatusRanges(t *testing.T) {
+	ranges, err := parseStatusRanges(statusValues("2xx", "404", "300-302"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expected := []statusRange{{200, 299}, {404, 404}, {300, 302}}
+	if len(ranges) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, ranges)
+	}
+	for i := range expected {
+		if ranges[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected[i], ranges[i])
+		}
+	}
+
+	for _, invalid := range []string{"2XX", "99", "600", "204-200", "abc"} {
+		if _, err := parseStatusRanges(statusValues(invalid)); err == nil {
+			t.Errorf("Expected %q to be rejected", invalid)
+		}
+	}
+}
+
+func TestRestResource_StatusPolicyEvaluate(t *testing.T) {
+	ctx := context.Background()
+	r := &RestResource{}
+
+	tests := []struct {
+		name      string
+		data      *RestResourceModel
+		operation string
+		status    int
+		outcome   statusOutcome
+		action    string
+		retry     bool
+	}{
+		{
+			name:      "default read success",
+			data:      &RestResourceModel{},
+			operation: "read",
+			status:    200,
+			outcome:   statusSucceeded,
+			action:    "continue",
+		},
+		{
+			name:      "default read gone",
+			data:      &RestResourceModel{},
+			operation: "read",
+			status:    410,
+			outcome:   statusGone,
+		},
+		{
+			name:      "create has no gone statuses",
+			data:      &RestResourceModel{},
+			operation: "create",
+			status:    404,
+			outcome:   statusFailed,
+			action:    "fail",
+		},
+		{
+			name: "configured gone status",
+			data: &RestResourceModel{StatusPolicy: &StatusPoliciesModel{
+				Read: &StatusPolicyModel{Gone: statusValues("403", "404")},
+			}},
+			operation: "read",
+			status:    403,
+			outcome:   statusGone,
+		},
+		{
+			name: "fail overrides success",
+			data: &RestResourceModel{StatusPolicy: &StatusPoliciesModel{
+				Update: &StatusPolicyModel{Fail: statusValues("202")},
+			}},
+			operation: "update",
+			status:    202,
+			outcome:   statusFailed,
+			action:    "fail",
+		},
+		{
+			name: "retry status",
+			data: &RestResourceModel{StatusPolicy: &StatusPoliciesModel{
+				Delete: &StatusPolicyModel{Retry: statusValues("423")},
+			}},
+			operation: "delete",
+			status:    423,
+			outcome:   statusFailed,
+			action:    "fail",
+			retry:     true,
+		},
+		{
+			name: "on_success retry only retries 202",
+			data: &RestResourceModel{StatusPolicy: &StatusPoliciesModel{
+				Update: &StatusPolicyModel{OnSuccess: types.StringValue("retry")},
+			}},
+			operation: "update",
+			status:    200,
+			outcome:   statusSucceeded,
+			action:    "retry",
+		},
+		{
+			name: "legacy create settings",
+			data: &RestResourceModel{
+				ExpectedStatus: types.ListValueMust(types.Int64Type, []attr.Value{types.Int64Value(201), types.Int64Value(409)}),
+				FailOnStatus:   types.ListNull(types.Int64Type),
+				RetryOnStatus:  types.ListNull(types.Int64Type),
+				OnFailure:      types.StringValue("continue"),
+			},
+			operation: "create",
+			status:    200,
+			outcome:   statusFailed,
+			action:    "continue",
+		},
+		{
+			name: "status_policy create overrides legacy settings",
+			data: &RestResourceModel{
+				ExpectedStatus: types.ListValueMust(types.Int64Type, []attr.Value{types.Int64Value(201)}),
+				StatusPolicy:   &StatusPoliciesModel{Create: &StatusPolicyModel{Success: statusValues("200-201")}},
+			},
+			operation: "create",
+			status:    200,
+			outcome:   statusSucceeded,
+			action:    "continue",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := r.statusPolicy(ctx, tt.data, tt.operation)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			decision := policy.evaluate(tt.status)
+			if decision.outcome != tt.outcome || decision.action != tt.action || decision.retry != tt.retry {
+				t.Errorf("Expected outcome %d, action %q, retry %v; got %d, %q, %v", tt.outcome, tt.action, tt.retry, decision.outcome, decision.action, decision.retry)
+			}
+		})
+	}
+}
+
+func TestRestResource_DoWithStatusPolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []int
+		policy   *StatusPolicyModel
+		requests int32
+		status   int
+		outcome  statusOutcome
+	}{
+		{
+			name:     "retry status until success",
+			statuses: []int{423, 423, 200},
+			policy:   &StatusPolicyModel{Retry: statusValues("423")},
+			requests: 3,
+			status:   200,
+			outcome:  statusSucceeded,
+		},
+		{
+			name:     "on_success retry until not accepted",
+			statuses: []int{202, 202, 200},
+			policy:   &StatusPolicyModel{OnSuccess: types.StringValue("retry")},
+			requests: 3,
+			status:   200,
+			outcome:  statusSucceeded,
+		},
+		{
+			name:     "on_failure retry gives up",
+			statuses: []int{400, 400, 400},
+			policy:   &StatusPolicyModel{OnFailure: types.StringValue("retry"), MaxRetries: types.Int64Value(2)},
+			requests: 3,
+			status:   400,
+			outcome:  statusFailed,
+		},
+		{
+			name:     "exhausted 202 retries fail",
+			statuses: []int{202, 202},
+			policy:   &StatusPolicyModel{OnSuccess: types.StringValue("retry"), MaxRetries: types.Int64Value(1)},
+			requests: 2,
+			status:   202,
+			outcome:  statusFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := atomic.AddInt32(&requests, 1)
+				w.WriteHeader(tt.statuses[n-1])
+			}))
+			defer server.Close()
+
+			restClient, err := client.NewRestClient(client.Config{BaseURL: server.URL})
+			if err != nil {
+				t.Fatalf("Failed to create client: %s", err)
+			}
+			r := &RestResource{client: restClient}
+
+			model := tt.policy
+			model.RetryInterval = types.Int64Value(0)
+			if model.MaxRetries.IsNull() {
+				model.MaxRetries = types.Int64Value(5)
+			}
+			policy, err := r.statusPolicy(context.Background(), &RestResourceModel{StatusPolicy: &StatusPoliciesModel{Update: model}}, "update")
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			response, decision, err := doWithStatusPolicy(context.Background(), r.client, client.RequestOptions{Method: "PUT", Endpoint: "/items/1"}, policy)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if requests != tt.requests {
+				t.Errorf("Expected %d requests, got %d", tt.requests, requests)
+			}
+			if response.StatusCode != tt.status || decision.outcome != tt.outcome {
+				t.Errorf("Expected status %d with outcome %d, got %d with %d", tt.status, tt.outcome, response.StatusCode, decision.outcome)
+			}
+		})
+	}
+}
+
+func TestCheckFailedStatus(t *testing.T) {
+	response := &client.Response{StatusCode: 409, Body: []byte(`{"error":"conflict"}`)}
+
+	tests := []struct {
+		name      string
+		decision  statusDecision
+		expectErr bool
+	}{
+		{name: "success", decision: statusDecision{outcome: statusSucceeded, action: "continue"}},
+		{name: "gone is left to the caller", decision: statusDecision{outcome: statusGone}},
+		{name: "failure", decision: statusDecision{outcome: statusFailed, action: "fail"}, expectErr: true},
+		{name: "failure with continue", decision: statusDecision{outcome: statusFailed, action: "continue"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkFailedStatus(context.Background(), response, tt.decision)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("Expected error %v, got %v", tt.expectErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	dschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-rest/internal/client"
+)
+
+// timingsAttrTypes describes the computed timings object shared by the resource and data source
+var timingsAttrTypes = map[string]attr.Type{
+	"dns_lookup_ms":         types.Float64Type,
+	"connect_ms":            types.Float64Type,
+	"tls_handshake_ms":      types.Float64Type,
+	"time_to_first_byte_ms": types.Float64Type,
+	"total_ms":              types.Float64Type,
+	"attempts":              types.Int64Type,
+	"connection_reused":     types.BoolType,
+}
+
+// timingsAttributeDescriptions documents each timings attribute
+var timingsAttributeDescriptions = map[string]string{
+	"dns_lookup_ms":         "Time spent resolving the host name, in milliseconds. Zero when a pooled connection was reused.",
+	"connect_ms":            "Time spent establishing the TCP connection, in milliseconds.",
+	"tls_handshake_ms":      "Time spent in the TLS handshake, in milliseconds.",
+	"time_to_first_byte_ms": "Time from sending the request until the first response byte arrived, in milliseconds.",
+	"total_ms":              "Total time for the request across all attempts, including retry backoff, in milliseconds.",
+	"attempts":              "Number of attempts made, including retries.",
+	"connection_reused":     "Whether the final attempt reused a pooled connection.",
+}
+
+// timingsDescription is the description of the timings attribute
+const timingsDescription = "Timing and connection telemetry for the most recent API request."
+
+// resourceTimingsAttribute builds the computed timings attribute for resource schemas
+func resourceTimingsAttribute() rschema.SingleNestedAttribute {
+	attributes := make(map[string]rschema.Attribute, len(timingsAttrTypes))
+	for name, attrType := range timingsAttrTypes {
+		description := timingsAttributeDescriptions[name]
+		switch attrType {
+		case types.Int64Type:
+			attributes[name] = rschema.Int64Attribute{MarkdownDescription: description, Computed: true}
+		case types.BoolType:
+			attributes[name] = rschema.BoolAttribute{MarkdownDescription: description, Computed: true}
+		default:
+			attributes[name] = rschema.Float64Attribute{MarkdownDescription: description, Computed: true}
+		}
+	}
+
+	return rschema.SingleNestedAttribute{
+		MarkdownDescription: timingsDescription,
+		Computed:            true,
+		Attributes:          attributes,
+	}
+}
+
+// dataSourceTimingsAttribute builds the computed timings attribute for data
+// source schemas from the resource one
+func dataSourceTimingsAttribute() dschema.SingleNestedAttribute {
+	resourceAttribute := resourceTimingsAttribute()
+
+	attributes := make(map[string]dschema.Attribute, len(resourceAttribute.Attributes))
+	for name, attribute := range resourceAttribute.Attributes {
+		switch a := attribute.(type) {
+		case rschema.Int64Attribute:
+			attributes[name] = dschema.Int64Attribute{MarkdownDescription: a.MarkdownDescription, Computed: true}
+		case rschema.BoolAttribute:
+			attributes[name] = dschema.BoolAttribute{MarkdownDescription: a.MarkdownDescription, Computed: true}
+		case rschema.Float64Attribute:
+			attributes[name] = dschema.Float64Attribute{MarkdownDescription: a.MarkdownDescription, Computed: true}
+		}
+	}
+
+	return dschema.SingleNestedAttribute{
+		MarkdownDescription: resourceAttribute.MarkdownDescription,
+		Computed:            true,
+		Attributes:          attributes,
+	}
+}
+
+// timingsObjectValue converts client timings into the computed timings object
+func timingsObjectValue(timings client.Timings) (types.Object, diag.Diagnostics) {
+	return types.ObjectValue(timingsAttrTypes, map[string]attr.Value{
+		"dns_lookup_ms":         types.Float64Value(client.DurationMillis(timings.DNSLookup)),
+		"connect_ms":            types.Float64Value(client.DurationMillis(timings.Connect)),
+		"tls_handshake_ms":      types.Float64Value(client.DurationMillis(timings.TLSHandshake)),
+		"time_to_first_byte_ms": types.Float64Value(client.DurationMillis(timings.TimeToFirstByte)),
+		"total_ms":              types.Float64Value(client.DurationMillis(timings.Total)),
+		"attempts":              types.Int64Value(int64(timings.Attempts)),
+		"connection_reused":     types.BoolValue(timings.ConnectionReused),
+	})
+}
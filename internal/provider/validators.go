@@ -0,0 +1,70 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// regexValidator checks that a string is a valid regular expression, so a typo
+// fails at plan time rather than after a request has been sent
+type regexValidator struct{}
+
+// validRegex returns a validator for regular expression attributes
+func validRegex() validator.String {
+	return regexValidator{}
+}
+
+func (v regexValidator) Description(ctx context.Context) string {
+	return "value must be a valid regular expression"
+}
+
+func (v regexValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v regexValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if _, err := regexp.Compile(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Regular Expression", err.Error())
+	}
+}
+
+// jsonPathValidator checks that a string is a dotted path or JSON pointer that
+// lookupJSONPath can resolve, so without wildcards
+type jsonPathValidator struct{}
+
+// validJSONPath returns a validator for JSON path attributes
+func validJSONPath() validator.String {
+	return jsonPathValidator{}
+}
+
+func (v jsonPathValidator) Description(ctx context.Context) string {
+	return "value must be a dotted path or JSON pointer without wildcards"
+}
+
+func (v jsonPathValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v jsonPathValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	segments, err := parseJSONPath(req.ConfigValue.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid JSON Path", err.Error())
+		return
+	}
+	for _, segment := range segments {
+		if segment.wildcard {
+			resp.Diagnostics.AddAttributeError(req.Path, "Invalid JSON Path", "wildcards are not supported here")
+			return
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestStringValidators(t *testing.T) {
+	tests := []struct {
+		name      string
+		validator validator.String
+		value     types.String
+		expectErr bool
+	}{
+		{name: "valid regex", validator: validRegex(), value: types.StringValue(`/items/([^/?]+)$`)},
+		{name: "invalid regex", validator: validRegex(), value: types.StringValue(`/items/([^/?]+$`), expectErr: true},
+		{name: "null regex", validator: validRegex(), value: types.StringNull()},
+		{name: "unknown regex", validator: validRegex(), value: types.StringUnknown()},
+		{name: "dotted path", validator: validJSONPath(), value: types.StringValue("$.items[0].id")},
+		{name: "JSON pointer", validator: validJSONPath(), value: types.StringValue("/data/uuid")},
+		{name: "unterminated index", validator: validJSONPath(), value: types.StringValue("items[0"), expectErr: true},
+		{name: "invalid index", validator: validJSONPath(), value: types.StringValue("items[x]"), expectErr: true},
+		{name: "wildcard", validator: validJSONPath(), value: types.StringValue("items[*].id"), expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := validator.StringRequest{Path: path.Root("test"), ConfigValue: tt.value}
+			resp := &validator.StringResponse{}
+
+			tt.validator.ValidateString(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != tt.expectErr {
+				t.Errorf("Expected error %v, got %v", tt.expectErr, resp.Diagnostics)
+			}
+		})
+	}
+}
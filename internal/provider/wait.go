@@ -0,0 +1,300 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"terraform-provider-rest/internal/client"
+)
+
+const (
+	defaultWaitInterval    = 5 * time.Second
+	defaultWaitMaxInterval = time.Minute
+	defaultWaitTimeout     = 10 * time.Minute
+)
+
+// WaitForReadyModel describes the condition an object must reach after create
+// or update before the operation is considered complete.
+type WaitForReadyModel struct {
+	Path          types.String   `tfsdk:"path"`
+	Values        []types.String `tfsdk:"values"`
+	FailureValues []types.String `tfsdk:"failure_values"`
+	Interval      types.Int64    `tfsdk:"interval"`
+	MaxInterval   types.Int64    `tfsdk:"max_interval"`
+	Backoff       types.Float64  `tfsdk:"backoff"`
+	Timeout       types.Int64    `tfsdk:"timeout"`
+}
+
+// WaitForDeleteModel describes how to tell that a deleted object is gone.
+type WaitForDeleteModel struct {
+	Path        types.String   `tfsdk:"path"`
+	Values      []types.String `tfsdk:"values"`
+	Interval    types.Int64    `tfsdk:"interval"`
+	MaxInterval types.Int64    `tfsdk:"max_interval"`
+	Backoff     types.Float64  `tfsdk:"backoff"`
+	Timeout     types.Int64    `tfsdk:"timeout"`
+}
+
+// waitSchedule controls how often and how long a waiter polls
+type waitSchedule struct {
+	interval    time.Duration
+	maxInterval time.Duration
+	backoff     float64
+	timeout     time.Duration
+}
+
+// newWaitSchedule applies defaults to the timing attributes shared by waiters
+func newWaitSchedule(interval, maxInterval types.Int64, backoff types.Float64, timeout types.Int64) waitSchedule {
+	schedule := waitSchedule{
+		interval:    defaultWaitInterval,
+		maxInterval: defaultWaitMaxInterval,
+		backoff:     1,
+		timeout:     defaultWaitTimeout,
+	}
+	if !interval.IsNull() {
+		schedule.interval = time.Duration(interval.ValueInt64()) * time.Second
+	}
+	if !maxInterval.IsNull() {
+		schedule.maxInterval = time.Duration(maxInterval.ValueInt64()) * time.Second
+	}
+	if !backoff.IsNull() {
+		schedule.backoff = backoff.ValueFloat64()
+	}
+	if !timeout.IsNull() {
+		schedule.timeout = time.Duration(timeout.ValueInt64()) * time.Second
+	}
+	if schedule.maxInterval < schedule.interval {
+		schedule.maxInterval = schedule.interval
+	}
+	return schedule
+}
+
+// waitTimingAttributes returns the interval, backoff and timeout attributes
+// shared by wait_for_ready and wait_for_delete
+func waitTimingAttributes(attributes map[string]schema.Attribute) map[string]schema.Attribute {
+	attributes["interval"] = schema.Int64Attribute{
+		MarkdownDescription: "Seconds between checks. Default: 5.",
+		Optional:            true,
+		Validators: []validator.Int64{
+			int64validator.AtLeast(1),
+		},
+	}
+	attributes["max_interval"] = schema.Int64Attribute{
+		MarkdownDescription: "Upper bound in seconds for the interval as it grows with `backoff`. Default: 60.",
+		Optional:            true,
+		Validators: []validator.Int64{
+			int64validator.AtLeast(1),
+		},
+	}
+	attributes["backoff"] = schema.Float64Attribute{
+		MarkdownDescription: "Factor the interval is multiplied by after each check, e.g. `2` doubles it. Default: 1 (constant interval).",
+		Optional:            true,
+		Validators: []validator.Float64{
+			float64validator.AtLeast(1),
+		},
+	}
+	attributes["timeout"] = schema.Int64Attribute{
+		MarkdownDescription: "Maximum seconds to wait. Default: 600.",
+		Optional:            true,
+		Validators: []validator.Int64{
+			int64validator.AtLeast(1),
+		},
+	}
+	return attributes
+}
+
+// waitForReadyAttribute returns the schema for the wait_for_ready block
+func waitForReadyAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "After create and update, read the object until a JSON path reaches one of `values`, e.g. `state` is `ACTIVE`. State is saved from the final read.",
+		Optional:            true,
+		Attributes: waitTimingAttributes(map[string]schema.Attribute{
+			"path": schema.StringAttribute{
+				MarkdownDescription: "JSON path or pointer to check in the read response, e.g. `$.state` or `/status/phase`.",
+				Required:            true,
+			},
+			"values": schema.ListAttribute{
+				MarkdownDescription: "Values that mean the object is ready, e.g. `[\"ACTIVE\"]`.",
+				ElementType:         types.StringType,
+				Required:            true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"failure_values": schema.ListAttribute{
+				MarkdownDescription: "Values that mean the object will never become ready, e.g. `[\"ERROR\"]`. Waiting stops with an error.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+		}),
+	}
+}
+
+// waitForDeleteAttribute returns the schema for the wait_for_delete block
+func waitForDeleteAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "After delete, read the object until it is gone: a `404` or `410` response, or a body where `path` has one of `values` (e.g. `state` is `DELETED`).",
+		Optional:            true,
+		Attributes: waitTimingAttributes(map[string]schema.Attribute{
+			"path": schema.StringAttribute{
+				MarkdownDescription: "JSON path or pointer to check in the read response for soft-deleted objects.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("values")),
+				},
+			},
+			"values": schema.ListAttribute{
+				MarkdownDescription: "Values at `path` that mean the object is gone, e.g. `[\"DELETED\"]`.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.List{
+					listvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("path")),
+				},
+			},
+		}),
+	}
+}
+
+// waitCheck inspects a read response and reports whether waiting is over. The
+// returned state is logged to show progress.
+type waitCheck func(response *client.Response, decision statusDecision) (done bool, state string, err error)
+
+// waitForObject reads the object on the given schedule until check reports done
+// or fails, returning the final read response.
+func (r *RestResource) waitForObject(ctx context.Context, data *RestResourceModel, schedule waitSchedule, purpose string, check waitCheck) (*client.Response, error) {
+	start := time.Now()
+	deadline := start.Add(schedule.timeout)
+	interval := schedule.interval
+	state := ""
+
+	for attempt := 1; ; attempt++ {
+		response, decision, endpoint, err := r.readObject(ctx, data)
+		if err != nil {
+			return nil, fmt.Errorf("waiting for the object to be %s: %w", purpose, err)
+		}
+
+		done, current, err := check(response, decision)
+		if err != nil {
+			return nil, fmt.Errorf("waiting for %s to be %s: %w", endpoint, purpose, err)
+		}
+		state = current
+
+		tflog.Info(ctx, "waiting for object", map[string]interface{}{
+			"endpoint":    endpoint,
+			"purpose":     purpose,
+			"attempt":     attempt,
+			"elapsed":     time.Since(start).Round(time.Second).String(),
+			"status_code": response.StatusCode,
+			"state":       state,
+			"done":        done,
+		})
+
+		if done {
+			return response, nil
+		}
+
+		if time.Now().Add(interval).After(deadline) {
+			return nil, fmt.Errorf("timed out after %s waiting for the object to be %s; last state %q", schedule.timeout, purpose, state)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+
+		interval = time.Duration(float64(interval) * schedule.backoff)
+		if interval > schedule.maxInterval {
+			interval = schedule.maxInterval
+		}
+	}
+}
+
+// waitForReady waits until the object reaches a wait_for_ready value and records
+// the final read in the model
+func (r *RestResource) waitForReady(ctx context.Context, data *RestResourceModel) error {
+	wait := data.WaitForReady
+	schedule := newWaitSchedule(wait.Interval, wait.MaxInterval, wait.Backoff, wait.Timeout)
+	ready := stringSet(wait.Values)
+	failed := stringSet(wait.FailureValues)
+
+	response, err := r.waitForObject(ctx, data, schedule, "ready", func(response *client.Response, decision statusDecision) (bool, string, error) {
+		// The object may not be visible yet right after it is created
+		if decision.outcome == statusGone {
+			return false, "not found", nil
+		}
+		if checkFailedStatus(ctx, response, decision) != nil {
+			return false, "", fmt.Errorf("read returned status %d: %s", response.StatusCode, string(response.Body))
+		}
+
+		value, found, err := responseValue(response, wait.Path.ValueString())
+		if err != nil || !found {
+			return false, "", err
+		}
+		if failed[value] {
+			return false, value, fmt.Errorf("%s is %q: %s", wait.Path.ValueString(), value, string(response.Body))
+		}
+		return ready[value], value, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return r.processResponse(ctx, response, data)
+}
+
+// waitForDelete waits until the object is gone after a delete request
+func (r *RestResource) waitForDelete(ctx context.Context, data *RestResourceModel) error {
+	wait := data.WaitForDelete
+	schedule := newWaitSchedule(wait.Interval, wait.MaxInterval, wait.Backoff, wait.Timeout)
+	gone := stringSet(wait.Values)
+
+	_, err := r.waitForObject(ctx, data, schedule, "deleted", func(response *client.Response, decision statusDecision) (bool, string, error) {
+		outcome, err := r.readGone(ctx, data, response, decision)
+		if err != nil {
+			return false, "", err
+		}
+		switch outcome {
+		case statusGone:
+			return true, "gone", nil
+		case statusFailed:
+			return false, "", fmt.Errorf("read returned status %d: %s", response.StatusCode, string(response.Body))
+		}
+		if wait.Path.IsNull() {
+			return false, "present", nil
+		}
+
+		value, found, err := responseValue(response, wait.Path.ValueString())
+		if err != nil || !found {
+			return false, "present", err
+		}
+		return gone[value], value, nil
+	})
+	return err
+}
+
+// responseValue looks up a scalar value in a JSON response body. Bodies that are
+// not JSON and missing values are reported as not found.
+func responseValue(response *client.Response, expr string) (string, bool, error) {
+	document, err := decodeJSONDocument(response.Body)
+	if err != nil {
+		return "", false, nil
+	}
+	value, found, err := lookupJSONPath(document, expr)
+	if err != nil || !found {
+		return "", false, err
+	}
+	result, ok := jsonScalarString(value)
+	return result, ok, nil
+}
@@ -0,0 +1,232 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-rest/internal/client"
+)
+
+// newWaitTestResource serves successive read responses for /items/42 from the
+// given list, repeating the last one
+func newWaitTestResource(t *testing.T, responses []func(w http.ResponseWriter)) (*RestResource, *int32) {
+	t.Helper()
+
+	var reads int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/items/42" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		n := int(atomic.AddInt32(&reads, 1))
+		if n > len(responses) {
+			n = len(responses)
+		}
+		responses[n-1](w)
+	}))
+	t.Cleanup(server.Close)
+
+	restClient, err := client.NewRestClient(client.Config{BaseURL: server.URL, RetryAttempts: 1})
+	if err != nil {
+		t.Fatalf("Failed to create client: %s", err)
+	}
+	return &RestResource{client: restClient}, &reads
+}
+
+func jsonReply(status int, body string) func(w http.ResponseWriter) {
+	return func(w http.ResponseWriter) {
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}
+}
+
+func TestRestResource_WaitForObject(t *testing.T) {
+	r, reads := newWaitTestResource(t, []func(w http.ResponseWriter){
+		jsonReply(404, ``),
+		jsonReply(200, `{"state": "PROVISIONING"}`),
+		jsonReply(200, `{"state": "ACTIVE"}`),
+	})
+	data := &RestResourceModel{Endpoint: types.StringValue("/items"), Id: types.StringValue("42")}
+	schedule := waitSchedule{interval: time.Millisecond, maxInterval: 4 * time.Millisecond, backoff: 2, timeout: time.Second}
+
+	response, err := r.waitForObject(context.Background(), data, schedule, "ready", func(response *client.Response, decision statusDecision) (bool, string, error) {
+		value, _, err := responseValue(response, "$.state")
+		return value == "ACTIVE", value, err
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if *reads != 3 {
+		t.Errorf("Expected 3 reads, got %d", *reads)
+	}
+	if !strings.Contains(string(response.Body), "ACTIVE") {
+		t.Errorf("Expected the final read response, got %s", response.Body)
+	}
+
+	// A condition that never holds runs into the timeout
+	schedule.timeout = 20 * time.Millisecond
+	_, err = r.waitForObject(context.Background(), data, schedule, "deleted", func(response *client.Response, decision statusDecision) (bool, string, error) {
+		return false, "present", nil
+	})
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("Expected timeout error, got %v", err)
+	}
+}
+
+func TestRestResource_WaitForReady(t *testing.T) {
+	tests := []struct {
+		name   string
+		reply  func(w http.ResponseWriter)
+		errMsg string
+	}{
+		{name: "ready value", reply: jsonReply(200, `{"id": "42", "state": "ACTIVE"}`)},
+		{name: "failure value", reply: jsonReply(200, `{"id": "42", "state": "ERROR"}`), errMsg: `is "ERROR"`},
+		{name: "read error", reply: jsonReply(403, `denied`), errMsg: "status 403"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, _ := newWaitTestResource(t, []func(w http.ResponseWriter){tt.reply})
+			data := &RestResourceModel{
+				Endpoint: types.StringValue("/items"),
+				Id:       types.StringValue("42"),
+				WaitForReady: &WaitForReadyModel{
+					Path:          types.StringValue("$.state"),
+					Values:        []types.String{types.StringValue("ACTIVE")},
+					FailureValues: []types.String{types.StringValue("ERROR")},
+				},
+			}
+
+			err := r.waitForReady(context.Background(), data)
+
+			if tt.errMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+					t.Errorf("Expected error containing %q, got %v", tt.errMsg, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			state, ok := data.ResponseData.Elements()["state"].(types.String)
+			if !ok || state.ValueString() != "ACTIVE" {
+				t.Errorf("Expected state to be saved from the final read, got %v", data.ResponseData)
+			}
+		})
+	}
+}
+
+func TestRestResource_WaitForReadyReadPolicy(t *testing.T) {
+	r, reads := newWaitTestResource(t, []func(w http.ResponseWriter){
+		jsonReply(503, `unavailable`),
+		jsonReply(200, `{"id": "42", "state": "ACTIVE"}`),
+	})
+	data := &RestResourceModel{
+		Endpoint: types.StringValue("/items"),
+		Id:       types.StringValue("42"),
+		StatusPolicy: &StatusPoliciesModel{Read: &StatusPolicyModel{
+			Retry:         statusValues("503"),
+			RetryInterval: types.Int64Value(0),
+		}},
+		WaitForReady: &WaitForReadyModel{
+			Path:   types.StringValue("$.state"),
+			Values: []types.String{types.StringValue("ACTIVE")},
+		},
+	}
+
+	// The 503 is retried by the read policy instead of failing the wait
+	if err := r.waitForReady(context.Background(), data); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if *reads != 2 {
+		t.Errorf("Expected 2 reads, got %d", *reads)
+	}
+}
+
+func TestRestResource_WaitForReadyStreaming(t *testing.T) {
+	r, reads := newWaitTestResource(t, []func(w http.ResponseWriter){
+		jsonReply(200, `{"id": "42", "state": "PROVISIONING"}`),
+		jsonReply(200, `{"id": "42", "state": "ACTIVE"}`),
+	})
+	spoolPath := filepath.Join(t.TempDir(), "response.json")
+	data := &RestResourceModel{
+		Endpoint:          types.StringValue("/items"),
+		Id:                types.StringValue("42"),
+		StreamResponse:    types.BoolValue(true),
+		ResponseSpoolPath: types.StringValue(spoolPath),
+		WaitForReady: &WaitForReadyModel{
+			Path:     types.StringValue("$.state"),
+			Values:   []types.String{types.StringValue("ACTIVE")},
+			Interval: types.Int64Value(1),
+		},
+	}
+
+	if err := r.waitForReady(context.Background(), data); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if *reads != 2 {
+		t.Errorf("Expected 2 reads, got %d", *reads)
+	}
+
+	// The reads inspect the body, but it stays out of state and the spool file
+	if data.Response.ValueString() != "" || len(data.ResponseData.Elements()) != 0 {
+		t.Errorf("Expected the streamed body to stay out of state, got %s", data.Response)
+	}
+	if data.ResponseSHA256.ValueString() == "" {
+		t.Errorf("Expected response_sha256 to be recorded")
+	}
+	if _, err := os.Stat(spoolPath); !os.IsNotExist(err) {
+		t.Errorf("Expected waiting not to write the spool file, got %v", err)
+	}
+}
+
+func TestRestResource_WaitForDelete(t *testing.T) {
+	tests := []struct {
+		name     string
+		reply    func(w http.ResponseWriter)
+		wait     WaitForDeleteModel
+		goneWhen *GoneWhenModel
+	}{
+		{name: "not found", reply: jsonReply(404, ``)},
+		{name: "gone", reply: jsonReply(410, ``)},
+		{
+			name:  "soft deleted body",
+			reply: jsonReply(200, `{"id": "42", "state": "DELETED"}`),
+			wait:  WaitForDeleteModel{Path: types.StringValue("state"), Values: []types.String{types.StringValue("DELETED")}},
+		},
+		{
+			name:     "gone_when match",
+			reply:    jsonReply(200, `{"id": "42", "deleted": true}`),
+			goneWhen: &GoneWhenModel{Path: types.StringValue("deleted"), Values: []types.String{types.StringValue("true")}, BodyPattern: types.StringNull()},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, reads := newWaitTestResource(t, []func(w http.ResponseWriter){tt.reply})
+			wait := tt.wait
+			data := &RestResourceModel{
+				Endpoint:      types.StringValue("/items"),
+				Id:            types.StringValue("42"),
+				WaitForDelete: &wait,
+				GoneWhen:      tt.goneWhen,
+			}
+
+			if err := r.waitForDelete(context.Background(), data); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if *reads != 1 {
+				t.Errorf("Expected 1 read, got %d", *reads)
+			}
+		})
+	}
+}